@@ -4,6 +4,15 @@ import (
 	// Load all hashing functions needed.
 	_ "crypto/sha256"
 	_ "crypto/sha512"
+
+	"github.com/gbrlsnchs/jwt/v3/internal"
+)
+
+var (
+	// ErrUnsupportedAlg is the error for an algorithm name that isn't registered.
+	ErrUnsupportedAlg = internal.NewError("jwt: unsupported algorithm")
+	// ErrInvalidKey is the error for a key whose type can't be used with an algorithm.
+	ErrInvalidKey = internal.NewError("jwt: invalid key for algorithm")
 )
 
 // Algorithm is an algorithm for both signing and verifying a JWT.
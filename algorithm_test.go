@@ -0,0 +1,63 @@
+package jwt_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/gbrlsnchs/jwt/v3"
+	"github.com/gbrlsnchs/jwt/v3/internal"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestNewAlgorithm(t *testing.T) {
+	secret := bytes.Repeat([]byte("s"), 64)
+	testCases := []struct {
+		name string
+		key  interface{}
+		err  error
+	}{
+		{"HS256", secret, nil},
+		{"HS384", secret, nil},
+		{"HS512", secret, nil},
+		{"HS256", hmacKey1, jwt.ErrHMACKeyTooShort},
+		{"HS256", rsaPrivateKey1, jwt.ErrInvalidKey},
+		{"RS256", rsaPrivateKey1, nil},
+		{"RS384", rsaPublicKey1, nil},
+		{"RS512", rsaPrivateKey1, nil},
+		{"PS256", rsaPublicKey1, nil},
+		{"PS384", rsaPrivateKey1, nil},
+		{"PS512", rsaPublicKey1, nil},
+		{"RS256", es256PrivateKey1, jwt.ErrInvalidKey},
+		{"ES256", es256PrivateKey1, nil},
+		{"ES384", es384PublicKey1, nil},
+		{"ES512", es512PrivateKey1, nil},
+		{"ES256K", es256kPrivateKey1, nil},
+		{"ES256", es384PrivateKey1, jwt.ErrECDSACurve},
+		{"ES256K", es256PublicKey1, jwt.ErrECDSACurve},
+		{"ES256", secret, jwt.ErrInvalidKey},
+		{"EdDSA", ed25519PrivateKey1, nil},
+		{"EdDSA", ed25519PublicKey1, nil},
+		{"EdDSA", ed448PrivateKey1, nil},
+		{"EdDSA", ed448PublicKey1, nil},
+		{"EdDSA", es256PublicKey1, jwt.ErrInvalidKey},
+		{"none", secret, jwt.ErrUnsupportedAlg},
+		{"", nil, jwt.ErrUnsupportedAlg},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			alg, err := jwt.NewAlgorithm(tc.name, tc.key)
+			if want, got := tc.err, err; !internal.ErrorIs(got, want) {
+				t.Fatalf("jwt.NewAlgorithm err mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+			if err != nil {
+				if alg != nil {
+					t.Errorf("jwt.NewAlgorithm returned a non-nil algorithm with an error: %#v", alg)
+				}
+				return
+			}
+			if want, got := tc.name, alg.Name(); got != want {
+				t.Errorf("jwt.Algorithm.Name mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+		})
+	}
+}
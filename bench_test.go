@@ -1,6 +1,9 @@
 package jwt_test
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
 	"testing"
 	"time"
 
@@ -59,6 +62,33 @@ func BenchmarkSign(b *testing.B) {
 
 }
 
+func BenchmarkSigner(b *testing.B) {
+	pl := jwt.Payload{Issuer: "gbrlsnchs", Subject: "someone", JWTID: "foobar"}
+	s, err := jwt.NewSigner(benchHS256, jwt.KeyID("kid"))
+	if err != nil {
+		b.Fatal(err)
+	}
+	var token []byte
+	b.Run("Sign", func(b *testing.B) {
+		b.ReportAllocs()
+		for n := 0; n < b.N; n++ {
+			if token, err = s.Sign(pl); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("AppendSign", func(b *testing.B) {
+		b.ReportAllocs()
+		for n := 0; n < b.N; n++ {
+			if token, err = s.AppendSign(token[:0], pl); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	benchRecv = token
+}
+
 func BenchmarkVerify(b *testing.B) {
 	var (
 		token = []byte(
@@ -78,3 +108,35 @@ func BenchmarkVerify(b *testing.B) {
 		}
 	})
 }
+
+// BenchmarkVerifier doesn't allocate for HS256, nor for ES256, whose verified tokens are cached.
+func BenchmarkVerifier(b *testing.B) {
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		b.Fatal(err)
+	}
+	algs := []jwt.Algorithm{
+		benchHS256,
+		jwt.NewES256(jwt.ECDSAPrivateKey(priv)),
+	}
+	for _, alg := range algs {
+		token, err := jwt.Sign(jwt.Payload{Issuer: "gbrlsnchs", Subject: "someone"}, alg)
+		if err != nil {
+			b.Fatal(err)
+		}
+		vr, err := jwt.NewVerifier(alg)
+		if err != nil {
+			b.Fatal(err)
+		}
+		b.Run(alg.Name(), func(b *testing.B) {
+			dst := make([]byte, 0, len(token))
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				if dst, _, err = vr.AppendPayload(dst[:0], token); err != nil {
+					b.Fatal(err)
+				}
+			}
+			benchRecv = dst
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/gbrlsnchs/jwt/v3/internal"
+)
+
+var errMalformed = internal.NewError("malformed token")
+
+func decode(args []string, stdin io.Reader, stdout io.Writer) error {
+	fs := flag.NewFlagSet("decode", flag.ContinueOnError)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	token, err := input(fs.Args(), stdin)
+	if err != nil {
+		return err
+	}
+	parts := bytes.Split(token, []byte("."))
+	if len(parts) != 3 {
+		return errMalformed
+	}
+	for i, name := range []string{"Header", "Payload"} {
+		b, err := base64.RawURLEncoding.DecodeString(string(parts[i]))
+		if err != nil {
+			return err
+		}
+		if err = printJSON(stdout, name, b); err != nil {
+			return err
+		}
+	}
+	return printTimes(stdout, parts[1], time.Now())
+}
+
+func printJSON(w io.Writer, name string, b []byte) error {
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, b, "", "  "); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintf(w, "%s:\n%s\n", name, buf.Bytes())
+	return err
+}
+
+// printTimes prints the time claims of the encoded payload p64 in a human-readable format.
+func printTimes(w io.Writer, p64 []byte, now time.Time) error {
+	b, err := base64.RawURLEncoding.DecodeString(string(p64))
+	if err != nil {
+		return err
+	}
+	var claims map[string]interface{}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	if err = dec.Decode(&claims); err != nil {
+		return err
+	}
+	for _, name := range []string{"iat", "nbf", "exp"} {
+		n, ok := claims[name].(json.Number)
+		if !ok {
+			continue
+		}
+		sec, err := n.Float64()
+		if err != nil {
+			return err
+		}
+		t := time.Unix(int64(sec), 0).UTC()
+		var note string
+		switch {
+		case name == "exp" && t.Before(now):
+			note = " (expired)"
+		case name != "exp" && t.After(now):
+			note = " (in the future)"
+		}
+		if _, err = fmt.Fprintf(w, "%s: %s%s\n", name, t.Format(time.RFC3339), note); err != nil {
+			return err
+		}
+	}
+	return nil
+}
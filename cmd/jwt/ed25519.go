@@ -0,0 +1,22 @@
+// +build go1.13
+
+package main
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ed25519"
+)
+
+func ed25519FromSeed(seed []byte) (crypto.PrivateKey, bool) {
+	if len(seed) != ed25519.SeedSize {
+		return nil, false
+	}
+	return ed25519.NewKeyFromSeed(seed), true
+}
+
+// ed25519Equal checks whether priv's public key is pub.
+func ed25519Equal(priv crypto.PrivateKey, pub crypto.PublicKey) bool {
+	pk, ok := pub.(ed25519.PublicKey)
+	return ok && bytes.Equal(priv.(ed25519.PrivateKey).Public().(ed25519.PublicKey), pk)
+}
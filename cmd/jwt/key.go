@@ -0,0 +1,114 @@
+package main
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"io/ioutil"
+	"math/big"
+	"strings"
+
+	"github.com/gbrlsnchs/jwt/v3"
+	"github.com/gbrlsnchs/jwt/v3/internal"
+	"github.com/gbrlsnchs/jwt/v3/keys"
+)
+
+var (
+	errNoKey          = internal.NewError("missing key")
+	errUnsupportedKey = internal.NewError("unsupported key")
+	errKeyMismatch    = internal.NewError("private key doesn't match public key")
+)
+
+// privateJWK is a JWK that may hold private or symmetric keys.
+type privateJWK struct {
+	jwt.JWK
+	D string `json:"d,omitempty"`
+	P string `json:"p,omitempty"`
+	Q string `json:"q,omitempty"`
+	K string `json:"k,omitempty"`
+}
+
+// loadKey reads a key from a PEM or JWK file. For HMAC algorithms, other files are used as raw secrets,
+// without trailing line breaks.
+func loadKey(path, alg string) (interface{}, error) {
+	if path == "" {
+		return nil, errNoKey
+	}
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	trimmed := bytes.TrimSpace(b)
+	switch {
+	case bytes.HasPrefix(trimmed, []byte("-----BEGIN")):
+		return keys.ParsePEM(trimmed)
+	case bytes.HasPrefix(trimmed, []byte("{")):
+		var jwk privateJWK
+		if err = json.Unmarshal(trimmed, &jwk); err != nil {
+			return nil, err
+		}
+		return jwk.key()
+	case strings.HasPrefix(alg, "HS"):
+		// Editors usually end files with a line break, which isn't meant to be part of the secret.
+		return bytes.TrimRight(b, "\r\n"), nil
+	}
+	return nil, errUnsupportedKey
+}
+
+func (jwk *privateJWK) key() (interface{}, error) {
+	if jwk.KeyType == "oct" {
+		return base64.RawURLEncoding.DecodeString(jwk.K)
+	}
+	pub, err := jwk.PublicKey()
+	if err != nil || jwk.D == "" {
+		return pub, err
+	}
+	d, err := base64.RawURLEncoding.DecodeString(jwk.D)
+	if err != nil {
+		return nil, err
+	}
+	switch pub := pub.(type) {
+	case *rsa.PublicKey:
+		priv := &rsa.PrivateKey{PublicKey: *pub, D: new(big.Int).SetBytes(d)}
+		if jwk.P == "" && jwk.Q == "" {
+			// The RFC 7518 allows omitting the primes, which Validate and Precompute need,
+			// so check that d inverts e instead.
+			m := big.NewInt(2)
+			c := new(big.Int).Exp(m, big.NewInt(int64(pub.E)), pub.N)
+			if priv.D.Sign() <= 0 || c.Exp(c, priv.D, pub.N).Cmp(m) != 0 {
+				return nil, errKeyMismatch
+			}
+			return priv, nil
+		}
+		for _, s := range []string{jwk.P, jwk.Q} {
+			prime, err := base64.RawURLEncoding.DecodeString(s)
+			if err != nil {
+				return nil, err
+			}
+			priv.Primes = append(priv.Primes, new(big.Int).SetBytes(prime))
+		}
+		if err = priv.Validate(); err != nil {
+			return nil, err
+		}
+		priv.Precompute()
+		return priv, nil
+	case *ecdsa.PublicKey:
+		priv := &ecdsa.PrivateKey{PublicKey: *pub, D: new(big.Int).SetBytes(d)}
+		if priv.D.Sign() <= 0 || priv.D.Cmp(pub.Curve.Params().N) >= 0 {
+			return nil, errKeyMismatch
+		}
+		if x, y := pub.Curve.ScalarBaseMult(d); x.Cmp(pub.X) != 0 || y.Cmp(pub.Y) != 0 {
+			return nil, errKeyMismatch
+		}
+		return priv, nil
+	}
+	if priv, ok := ed25519FromSeed(d); ok {
+		if !ed25519Equal(priv, pub) {
+			return nil, errKeyMismatch
+		}
+		return priv, nil
+	}
+	return nil, errUnsupportedKey
+}
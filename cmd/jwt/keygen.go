@@ -0,0 +1,66 @@
+package main
+
+import (
+	"crypto"
+	"encoding/base64"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+
+	"github.com/gbrlsnchs/jwt/v3"
+	"github.com/gbrlsnchs/jwt/v3/keys"
+)
+
+func keygen(args []string, stdout io.Writer) error {
+	var (
+		fs      = flag.NewFlagSet("keygen", flag.ContinueOnError)
+		alg     = fs.String("alg", "", "algorithm the key is generated for, from HS256 to EdDSA")
+		bits    = fs.Int("bits", keys.MinRSABits, "size of RSA keys")
+		pubFile = fs.String("pub", "", "file to write the public key to, in PEM format")
+	)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	var (
+		priv crypto.PrivateKey
+		err  error
+	)
+	switch *alg {
+	case "RS256", "RS384", "RS512", "PS256", "PS384", "PS512":
+		priv, err = keys.GenerateRSA(*bits)
+	default:
+		priv, err = keys.Generate(*alg)
+	}
+	if err != nil {
+		return err
+	}
+	if secret, ok := priv.([]byte); ok {
+		// Secrets are written as JWKs, since they're not PEM encoded.
+		b, err := json.MarshalIndent(privateJWK{
+			JWK: jwt.JWK{KeyType: "oct", Algorithm: *alg},
+			K:   base64.RawURLEncoding.EncodeToString(secret),
+		}, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(stdout, "%s\n", b)
+		return err
+	}
+	b, err := keys.EncodePKCS8PrivateKey(priv)
+	if err != nil {
+		return err
+	}
+	if *pubFile != "" {
+		pub, err := keys.EncodeSPKIPublicKey(priv.(crypto.Signer).Public())
+		if err != nil {
+			return err
+		}
+		if err = ioutil.WriteFile(*pubFile, pub, 0644); err != nil {
+			return err
+		}
+	}
+	_, err = stdout.Write(b)
+	return err
+}
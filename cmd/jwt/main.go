@@ -0,0 +1,65 @@
+// Command jwt decodes, signs and verifies JWTs and generates keys for signing them.
+//
+// Usage:
+//
+//	jwt decode [token]
+//	jwt sign -alg alg [-key file] [flags] [claims]
+//	jwt verify -alg alg [-key file] [flags] [token]
+//	jwt keygen -alg alg [-bits n] [-pub file]
+//
+// Tokens and claims are read from stdin when not passed as arguments.
+// Keys are read from PEM or JWK files, or from raw secret files for HMAC algorithms,
+// whose trailing line breaks are not part of the secret.
+// Run "jwt <command> -h" for the flags of each command.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/gbrlsnchs/jwt/v3/internal"
+)
+
+var errUsage = internal.NewError("usage: jwt decode|sign|verify|keygen [flags] [args]")
+
+func main() {
+	if err := run(os.Args[1:], os.Stdin, os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, "jwt:", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string, stdin io.Reader, stdout io.Writer) error {
+	if len(args) == 0 {
+		return errUsage
+	}
+	cmd, args := args[0], args[1:]
+	switch cmd {
+	case "decode":
+		return decode(args, stdin, stdout)
+	case "sign":
+		return sign(args, stdin, stdout)
+	case "verify":
+		return verify(args, stdin, stdout)
+	case "keygen":
+		return keygen(args, stdout)
+	}
+	return errUsage
+}
+
+// input returns the first positional argument or, when there's none, what is read from stdin.
+// Surrounding whitespace is removed.
+func input(args []string, stdin io.Reader) ([]byte, error) {
+	if len(args) > 0 {
+		return []byte(strings.TrimSpace(args[0])), nil
+	}
+	b, err := ioutil.ReadAll(stdin)
+	if err != nil {
+		return nil, err
+	}
+	return bytes.TrimSpace(b), nil
+}
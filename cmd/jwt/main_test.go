@@ -0,0 +1,223 @@
+package main
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/gbrlsnchs/jwt/v3"
+	"github.com/gbrlsnchs/jwt/v3/internal"
+	"github.com/gbrlsnchs/jwt/v3/keys"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestRun(t *testing.T) {
+	dir, err := ioutil.TempDir("", "jwt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	exec := func(stdin string, args ...string) (string, error) {
+		var stdout bytes.Buffer
+		err := run(args, strings.NewReader(stdin), &stdout)
+		return stdout.String(), err
+	}
+	testCases := []string{
+		"HS256", "HS384", "HS512",
+		"RS256", "RS384", "RS512",
+		"PS256", "PS384", "PS512",
+		"ES256", "ES384", "ES512",
+		"ES256K",
+		"EdDSA",
+	}
+	for _, alg := range testCases {
+		t.Run(alg, func(t *testing.T) {
+			var (
+				privFile = filepath.Join(dir, alg+".key")
+				pubFile  = filepath.Join(dir, alg+".pub")
+			)
+			key, err := exec("", "keygen", "-alg", alg, "-pub", pubFile)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err = ioutil.WriteFile(privFile, []byte(key), 0600); err != nil {
+				t.Fatal(err)
+			}
+			if strings.HasPrefix(alg, "HS") {
+				pubFile = privFile
+			}
+			token, err := exec(`{"name":"John Doe"}`, "sign", "-alg", alg, "-key", privFile, "-sub", "1234567890", "-aud", "cli", "-exp", "1h")
+			if err != nil {
+				t.Fatal(err)
+			}
+			out, err := exec("", "verify", "-alg", alg, "-key", pubFile, "-sub", "1234567890", "-aud", "cli", "-exp", token)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !strings.Contains(out, `"name": "John Doe"`) {
+				t.Errorf("verify output lacks custom claim:\n%s", out)
+			}
+			if _, err = exec(token, "verify", "-alg", alg, "-key", pubFile, "-sub", "someone else"); err == nil {
+				t.Errorf("want verify error for wrong subject")
+			}
+		})
+	}
+}
+
+func TestSignClaims(t *testing.T) {
+	dir, err := ioutil.TempDir("", "jwt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	keyFile := filepath.Join(dir, "key")
+	if err = ioutil.WriteFile(keyFile, bytes.Repeat([]byte("k"), 32), 0600); err != nil {
+		t.Fatal(err)
+	}
+	testCases := []struct {
+		claims string
+		err    error
+	}{
+		{"", nil},
+		{"{}", nil},
+		{"null", errClaims},
+		{"[]", errClaims},
+		{`"claims"`, errClaims},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.claims, func(t *testing.T) {
+			var stdout bytes.Buffer
+			err := run([]string{"sign", "-alg", "HS256", "-key", keyFile, "-sub", "1234567890"}, strings.NewReader(tc.claims), &stdout)
+			if want, got := tc.err, err; !internal.ErrorIs(got, want) {
+				t.Errorf("jwt sign err mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+		})
+	}
+}
+
+func TestDecode(t *testing.T) {
+	const token = "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9." +
+		"eyJzdWIiOiIxMjM0NTY3ODkwIiwibmFtZSI6IkpvaG4gRG9lIiwiaWF0IjoxNTE2MjM5MDIyfQ." +
+		"SflKxwRJSMeKKF2QT4fwpMeJf36POk6yJV_adQssw5c"
+	var stdout bytes.Buffer
+	if err := run([]string{"decode"}, strings.NewReader(token+"\n"), &stdout); err != nil {
+		t.Fatal(err)
+	}
+	want := `Header:
+{
+  "alg": "HS256",
+  "typ": "JWT"
+}
+Payload:
+{
+  "sub": "1234567890",
+  "name": "John Doe",
+  "iat": 1516239022
+}
+iat: 2018-01-18T01:30:22Z
+`
+	if got := stdout.String(); got != want {
+		t.Errorf("jwt decode mismatch (-want +got):\n%s", cmp.Diff(want, got))
+	}
+}
+
+func TestLoadKey(t *testing.T) {
+	dir, err := ioutil.TempDir("", "jwt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecJWK := func(d *big.Int) string {
+		jwk, err := jwt.NewJWK(&priv.PublicKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, err := json.Marshal(privateJWK{JWK: *jwk, D: base64.RawURLEncoding.EncodeToString(d.Bytes())})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(b)
+	}
+	rsaPriv, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rsaJWK := func(d *big.Int) string {
+		jwk, err := jwt.NewJWK(&rsaPriv.PublicKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// Only "d" is set, as the RFC 7518 allows.
+		b, err := json.Marshal(privateJWK{JWK: *jwk, D: base64.RawURLEncoding.EncodeToString(d.Bytes())})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(b)
+	}
+	testCases := []struct {
+		name    string
+		alg     string
+		content string
+		want    interface{}
+		err     error
+	}{
+		{"secret", "HS256", "secret\n", []byte("secret"), nil},
+		{"secret with CRLF", "HS256", "secret\r\n", []byte("secret"), nil},
+		{"ECDSA JWK", "ES256", ecJWK(priv.D), priv, nil},
+		{"mismatched ECDSA JWK", "ES256", ecJWK(other.D), nil, errKeyMismatch},
+		{"RSA JWK without primes", "RS256", rsaJWK(rsaPriv.D), &rsa.PrivateKey{PublicKey: rsaPriv.PublicKey, D: rsaPriv.D}, nil},
+		{"mismatched RSA JWK without primes", "RS256", rsaJWK(new(big.Int).Add(rsaPriv.D, big.NewInt(1))), nil, errKeyMismatch},
+	}
+	for i, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(dir, strconv.Itoa(i))
+			if err := ioutil.WriteFile(path, []byte(tc.content), 0600); err != nil {
+				t.Fatal(err)
+			}
+			key, err := loadKey(path, tc.alg)
+			if want, got := tc.err, err; !internal.ErrorIs(got, want) {
+				t.Fatalf("loadKey err mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+			if want, got := tc.want, key; !reflect.DeepEqual(got, want) {
+				t.Errorf("loadKey mismatch: want %v, got %v", want, got)
+			}
+			if err != nil || strings.HasPrefix(tc.alg, "HS") {
+				return
+			}
+			alg, err := keys.Algorithm(tc.alg, key)
+			if err != nil {
+				t.Fatal(err)
+			}
+			token, err := jwt.Sign(jwt.Payload{Subject: "1234567890"}, alg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err = jwt.Verify(token, alg, &jwt.Payload{}); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+	"time"
+
+	"github.com/gbrlsnchs/jwt/v3"
+	"github.com/gbrlsnchs/jwt/v3/internal"
+	"github.com/gbrlsnchs/jwt/v3/keys"
+)
+
+var errClaims = internal.NewError("claims must be a JSON object")
+
+// listFlag is a flag that can be set multiple times.
+type listFlag []string
+
+func (l *listFlag) String() string { return strings.Join(*l, ",") }
+
+func (l *listFlag) Set(v string) error {
+	*l = append(*l, v)
+	return nil
+}
+
+func sign(args []string, stdin io.Reader, stdout io.Writer) error {
+	var (
+		fs      = flag.NewFlagSet("sign", flag.ContinueOnError)
+		alg     = fs.String("alg", "", "signing algorithm, from HS256 to EdDSA")
+		keyFile = fs.String("key", "", "PEM, JWK or, for HMAC algorithms, secret file")
+		claims  = fs.String("claims", "", "JSON file with the claims, instead of reading them from the arguments or stdin")
+		iss     = fs.String("iss", "", `"iss" claim`)
+		sub     = fs.String("sub", "", `"sub" claim`)
+		jti     = fs.String("jti", "", `"jti" claim`)
+		exp     = fs.Duration("exp", 0, `lifetime used for the "exp" claim`)
+		nbf     = fs.Duration("nbf", 0, `delay used for the "nbf" claim`)
+		iat     = fs.Bool("iat", true, `set the "iat" claim`)
+		kid     = fs.String("kid", "", `"kid" header parameter`)
+		typ     = fs.String("typ", "", `"typ" header parameter`)
+		aud     listFlag
+	)
+	fs.Var(&aud, "aud", `"aud" claim, which can be set multiple times`)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	key, err := loadKey(*keyFile, *alg)
+	if err != nil {
+		return err
+	}
+	a, err := keys.Algorithm(*alg, key)
+	if err != nil {
+		return err
+	}
+
+	var b []byte
+	if *claims != "" {
+		b, err = ioutil.ReadFile(*claims)
+	} else {
+		b, err = input(fs.Args(), stdin)
+	}
+	if err != nil {
+		return err
+	}
+	pl := make(map[string]interface{})
+	if len(b) > 0 {
+		var v interface{}
+		if err = json.Unmarshal(b, &v); err != nil {
+			return err
+		}
+		var ok bool
+		if pl, ok = v.(map[string]interface{}); !ok {
+			return errClaims
+		}
+	}
+	now := time.Now()
+	for name, v := range map[string]string{"iss": *iss, "sub": *sub, "jti": *jti} {
+		if v != "" {
+			pl[name] = v
+		}
+	}
+	switch len(aud) {
+	case 0:
+	case 1:
+		pl["aud"] = aud[0]
+	default:
+		pl["aud"] = aud
+	}
+	if *exp != 0 {
+		pl["exp"] = now.Add(*exp).Unix()
+	}
+	if *nbf != 0 {
+		pl["nbf"] = now.Add(*nbf).Unix()
+	}
+	if *iat {
+		pl["iat"] = now.Unix()
+	}
+
+	var opts []jwt.SignOption
+	if *kid != "" {
+		opts = append(opts, jwt.KeyID(*kid))
+	}
+	if *typ != "" {
+		opts = append(opts, jwt.Type(*typ))
+	}
+	token, err := jwt.Sign(pl, a, opts...)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(stdout, "%s\n", token)
+	return err
+}
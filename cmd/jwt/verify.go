@@ -0,0 +1,81 @@
+package main
+
+import (
+	"bytes"
+	"encoding/base64"
+	"flag"
+	"io"
+	"time"
+
+	"github.com/gbrlsnchs/jwt/v3"
+	"github.com/gbrlsnchs/jwt/v3/keys"
+)
+
+func verify(args []string, stdin io.Reader, stdout io.Writer) error {
+	var (
+		fs      = flag.NewFlagSet("verify", flag.ContinueOnError)
+		alg     = fs.String("alg", "", "signing algorithm, from HS256 to EdDSA")
+		keyFile = fs.String("key", "", "PEM, JWK or, for HMAC algorithms, secret file")
+		iss     = fs.String("iss", "", `required "iss" claim`)
+		sub     = fs.String("sub", "", `required "sub" claim`)
+		jti     = fs.String("jti", "", `required "jti" claim`)
+		aud     = fs.String("aud", "", `audience the "aud" claim must contain`)
+		typ     = fs.String("typ", "", `required "typ" header parameter`)
+		exp     = fs.Bool("exp", false, `require the "exp" claim`)
+	)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	key, err := loadKey(*keyFile, *alg)
+	if err != nil {
+		return err
+	}
+	a, err := keys.Algorithm(*alg, key)
+	if err != nil {
+		return err
+	}
+	token, err := input(fs.Args(), stdin)
+	if err != nil {
+		return err
+	}
+
+	var (
+		pl  jwt.Payload
+		now = time.Now()
+		vds = []jwt.Validator{
+			jwt.NotBeforeValidator(now),
+			jwt.IssuedAtValidator(now),
+			func(pl *jwt.Payload) error {
+				if pl.ExpirationTime == nil && !*exp {
+					return nil
+				}
+				return jwt.ExpirationTimeValidator(now)(pl)
+			},
+		}
+	)
+	if *iss != "" {
+		vds = append(vds, jwt.IssuerValidator(*iss))
+	}
+	if *sub != "" {
+		vds = append(vds, jwt.SubjectValidator(*sub))
+	}
+	if *jti != "" {
+		vds = append(vds, jwt.IDValidator(*jti))
+	}
+	if *aud != "" {
+		vds = append(vds, jwt.AudienceValidator(jwt.Audience{*aud}))
+	}
+	opts := []jwt.VerifyOption{jwt.ValidateHeader, jwt.ValidatePayload(&pl, vds...)}
+	if *typ != "" {
+		opts = append(opts, jwt.ValidateType(*typ))
+	}
+	if _, err = jwt.Verify(token, a, &pl, opts...); err != nil {
+		return err
+	}
+	parts := bytes.Split(token, []byte("."))
+	b, err := base64.RawURLEncoding.DecodeString(string(parts[1]))
+	if err != nil {
+		return err
+	}
+	return printJSON(stdout, "Payload", b)
+}
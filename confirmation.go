@@ -0,0 +1,103 @@
+package jwt
+
+import (
+	"crypto"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+
+	"github.com/gbrlsnchs/jwt/v3/internal"
+)
+
+// ErrCnfValidation is the error for an invalid "cnf" claim.
+var ErrCnfValidation = internal.NewError("jwt: cnf claim is invalid")
+
+// Confirmation is the "cnf" claim, which binds a token to a key held by its presenter, as per the RFC 7800.
+type Confirmation struct {
+	JSONWebKey *JWK   `json:"jwk,omitempty"`
+	KeyID      string `json:"kid,omitempty"`
+	// JWKThumbprint is the SHA-256 thumbprint of a JWK, as per the RFC 9449.
+	JWKThumbprint string `json:"jkt,omitempty"`
+	// X509Thumbprint is the SHA-256 thumbprint of an X.509 certificate, as per the RFC 8705.
+	X509Thumbprint string `json:"x5t#S256,omitempty"`
+}
+
+// CertificateConfirmation creates a Confirmation bound to cert.
+func CertificateConfirmation(cert *x509.Certificate) *Confirmation {
+	return &Confirmation{X509Thumbprint: certThumbprint(cert)}
+}
+
+// KeyConfirmation creates a Confirmation bound to the thumbprint of pub.
+func KeyConfirmation(pub crypto.PublicKey) (*Confirmation, error) {
+	jkt, err := keyThumbprint(pub)
+	if err != nil {
+		return nil, err
+	}
+	return &Confirmation{JWKThumbprint: jkt}, nil
+}
+
+// VerifyCertificate checks cert is the certificate the confirmation is bound to.
+func (cnf *Confirmation) VerifyCertificate(cert *x509.Certificate) error {
+	if cnf == nil || cert == nil || cnf.X509Thumbprint == "" {
+		return ErrCnfValidation
+	}
+	if cnf.X509Thumbprint != certThumbprint(cert) {
+		return ErrCnfValidation
+	}
+	return nil
+}
+
+// VerifyKey checks pub is the key the confirmation is bound to,
+// either by its embedded JWK or its JWK thumbprint.
+func (cnf *Confirmation) VerifyKey(pub crypto.PublicKey) error {
+	if cnf == nil || (cnf.JSONWebKey == nil && cnf.JWKThumbprint == "") {
+		return ErrCnfValidation
+	}
+	jkt, err := keyThumbprint(pub)
+	if err != nil {
+		return internal.Errorf("jwt: %v: %w", err, ErrCnfValidation)
+	}
+	if cnf.JSONWebKey != nil {
+		b, err := cnf.JSONWebKey.Thumbprint(crypto.SHA256)
+		if err != nil || base64.RawURLEncoding.EncodeToString(b) != jkt {
+			return ErrCnfValidation
+		}
+	}
+	if cnf.JWKThumbprint != "" && cnf.JWKThumbprint != jkt {
+		return ErrCnfValidation
+	}
+	return nil
+}
+
+// CertificateConfirmationValidator validates the "cnf" claim against a presented TLS client certificate.
+// Since the Validator only receives a Payload, cnf must point to the claim being decoded.
+func CertificateConfirmationValidator(cnf *Confirmation, cert *x509.Certificate) Validator {
+	return func(_ *Payload) error {
+		return cnf.VerifyCertificate(cert)
+	}
+}
+
+// KeyConfirmationValidator validates the "cnf" claim against a presented public key.
+// Since the Validator only receives a Payload, cnf must point to the claim being decoded.
+func KeyConfirmationValidator(cnf *Confirmation, pub crypto.PublicKey) Validator {
+	return func(_ *Payload) error {
+		return cnf.VerifyKey(pub)
+	}
+}
+
+func certThumbprint(cert *x509.Certificate) string {
+	sum := sha256.Sum256(cert.Raw)
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+func keyThumbprint(pub crypto.PublicKey) (string, error) {
+	jwk, err := NewJWK(pub)
+	if err != nil {
+		return "", err
+	}
+	b, err := jwk.Thumbprint(crypto.SHA256)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
@@ -0,0 +1,141 @@
+package jwt_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/gbrlsnchs/jwt/v3"
+	"github.com/gbrlsnchs/jwt/v3/internal"
+	"github.com/google/go-cmp/cmp"
+)
+
+var (
+	cert1 = genCertificate(es256PrivateKey1)
+	cert2 = genCertificate(es256PrivateKey2)
+)
+
+type cnfPayload struct {
+	jwt.Payload
+	Confirmation jwt.Confirmation `json:"cnf"`
+}
+
+func TestConfirmation(t *testing.T) {
+	certCnf := jwt.CertificateConfirmation(cert1)
+	keyCnf, err := jwt.KeyConfirmation(es256PublicKey1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	jwk, err := jwt.NewJWK(ed25519PublicKey1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	jwkCnf := &jwt.Confirmation{JSONWebKey: jwk}
+	testCases := []struct {
+		name string
+		cnf  *jwt.Confirmation
+		vd   func(*jwt.Confirmation) jwt.Validator
+		err  error
+	}{
+		{
+			name: "x5t#S256",
+			cnf:  certCnf,
+			vd: func(cnf *jwt.Confirmation) jwt.Validator {
+				return jwt.CertificateConfirmationValidator(cnf, cert1)
+			},
+			err: nil,
+		},
+		{
+			name: "x5t#S256 mismatch",
+			cnf:  certCnf,
+			vd: func(cnf *jwt.Confirmation) jwt.Validator {
+				return jwt.CertificateConfirmationValidator(cnf, cert2)
+			},
+			err: jwt.ErrCnfValidation,
+		},
+		{
+			name: "jkt",
+			cnf:  keyCnf,
+			vd: func(cnf *jwt.Confirmation) jwt.Validator {
+				return jwt.KeyConfirmationValidator(cnf, es256PublicKey1)
+			},
+			err: nil,
+		},
+		{
+			name: "jkt mismatch",
+			cnf:  keyCnf,
+			vd: func(cnf *jwt.Confirmation) jwt.Validator {
+				return jwt.KeyConfirmationValidator(cnf, es256PublicKey2)
+			},
+			err: jwt.ErrCnfValidation,
+		},
+		{
+			name: "jwk",
+			cnf:  jwkCnf,
+			vd: func(cnf *jwt.Confirmation) jwt.Validator {
+				return jwt.KeyConfirmationValidator(cnf, ed25519PublicKey1)
+			},
+			err: nil,
+		},
+		{
+			name: "jwk mismatch",
+			cnf:  jwkCnf,
+			vd: func(cnf *jwt.Confirmation) jwt.Validator {
+				return jwt.KeyConfirmationValidator(cnf, ed25519PublicKey2)
+			},
+			err: jwt.ErrCnfValidation,
+		},
+		{
+			name: "missing",
+			cnf:  &jwt.Confirmation{},
+			vd: func(cnf *jwt.Confirmation) jwt.Validator {
+				return jwt.CertificateConfirmationValidator(cnf, cert1)
+			},
+			err: jwt.ErrCnfValidation,
+		},
+		{
+			name: "certificate with key confirmation",
+			cnf:  keyCnf,
+			vd: func(cnf *jwt.Confirmation) jwt.Validator {
+				return jwt.CertificateConfirmationValidator(cnf, cert1)
+			},
+			err: jwt.ErrCnfValidation,
+		},
+	}
+	hs256 := jwt.NewHS256([]byte("secret"))
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			token, err := jwt.Sign(cnfPayload{Confirmation: *tc.cnf}, hs256)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var pl cnfPayload
+			_, err = jwt.Verify(token, hs256, &pl, jwt.ValidatePayload(&pl.Payload, tc.vd(&pl.Confirmation)))
+			if want, got := tc.err, err; !internal.ErrorIs(got, want) {
+				t.Errorf("jwt.Verify with cnf validators mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+		})
+	}
+}
+
+func genCertificate(priv *ecdsa.PrivateKey) *x509.Certificate {
+	tmpl := x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "client"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, &tmpl, &tmpl, &priv.PublicKey, priv)
+	if err != nil {
+		panic(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		panic(err)
+	}
+	return cert
+}
@@ -0,0 +1,105 @@
+// Package dpop implements Demonstrating Proof of Possession (DPoP) proofs, as per the RFC 9449.
+package dpop
+
+import (
+	"crypto"
+	"crypto/sha256"
+	"encoding/base64"
+	"time"
+
+	"github.com/gbrlsnchs/jwt/v3"
+	"github.com/gbrlsnchs/jwt/v3/internal"
+)
+
+// Type is the media type for DPoP proofs.
+const Type = "dpop+jwt"
+
+// ErrUnsupportedAlg is the error for an algorithm that can't be used with DPoP,
+// either because it is symmetric or because it doesn't expose its public key.
+var ErrUnsupportedAlg = internal.NewError("dpop: unsupported algorithm")
+
+// Proof is a DPoP proof payload.
+type Proof struct {
+	jwt.Payload
+	HTTPMethod      string `json:"htm"`
+	HTTPURI         string `json:"htu"`
+	AccessTokenHash string `json:"ath,omitempty"`
+	Nonce           string `json:"nonce,omitempty"`
+}
+
+// ProofOption is a functional option for creating proofs.
+type ProofOption func(*Proof)
+
+// AccessToken binds a proof to an access token by setting the "ath" claim.
+func AccessToken(token string) ProofOption {
+	return func(pf *Proof) {
+		pf.AccessTokenHash = AccessTokenHash(token)
+	}
+}
+
+// ID sets the "jti" claim of a proof. When not set, a random identifier is generated.
+func ID(jti string) ProofOption {
+	return func(pf *Proof) {
+		pf.JWTID = jti
+	}
+}
+
+// IssuedAt sets the "iat" claim of a proof. Defaults to time.Now.
+func IssuedAt(iat time.Time) ProofOption {
+	return func(pf *Proof) {
+		pf.IssuedAt = jwt.NumericDate(iat)
+	}
+}
+
+// Nonce sets the "nonce" claim of a proof with a value provided by the server.
+func Nonce(nonce string) ProofOption {
+	return func(pf *Proof) {
+		pf.Nonce = nonce
+	}
+}
+
+// NewProof creates a proof for an HTTP request with method and uri signed by alg.
+// The public key of alg is embedded in the proof's header, so alg must be asymmetric,
+// like jwt.ECDSASHA, jwt.Ed25519 or jwt.RSASHA.
+func NewProof(alg jwt.Algorithm, method, uri string, opts ...ProofOption) ([]byte, error) {
+	pk, ok := alg.(interface{ Public() crypto.PublicKey })
+	if !ok {
+		return nil, ErrUnsupportedAlg
+	}
+	jwk, err := jwt.NewJWK(pk.Public())
+	if err != nil {
+		return nil, err
+	}
+	pf := Proof{
+		HTTPMethod: method,
+		HTTPURI:    uri,
+	}
+	for _, opt := range opts {
+		opt(&pf)
+	}
+	if pf.IssuedAt == nil {
+		pf.IssuedAt = jwt.NumericDate(time.Now())
+	}
+	if pf.JWTID == "" {
+		if pf.JWTID, err = internal.RandomID(16); err != nil {
+			return nil, err
+		}
+	}
+	return jwt.Sign(pf, alg, jwt.Type(Type), jwt.JSONWebKey(jwk))
+}
+
+// AccessTokenHash computes the "ath" claim for an access token.
+func AccessTokenHash(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// Thumbprint computes the SHA-256 thumbprint of jwk encoded in Base64,
+// which is the value used in the "jkt" confirmation method and the "dpop_jkt" parameter.
+func Thumbprint(jwk *jwt.JWK) (string, error) {
+	b, err := jwk.Thumbprint(crypto.SHA256)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
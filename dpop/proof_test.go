@@ -0,0 +1,85 @@
+package dpop_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"testing"
+
+	"github.com/cloudflare/circl/sign/ed448"
+	"github.com/gbrlsnchs/jwt/v3"
+	"github.com/gbrlsnchs/jwt/v3/dpop"
+	"github.com/gbrlsnchs/jwt/v3/internal"
+	"github.com/google/go-cmp/cmp"
+)
+
+var (
+	es256PrivateKey, _      = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	rsaPrivateKey, _        = rsa.GenerateKey(rand.Reader, 2048)
+	ed25519PrivateKey, _    = internal.GenerateEd25519Keys()
+	es256kPrivateKey, _     = ecdsa.GenerateKey(jwt.Secp256k1(), rand.Reader)
+	_, ed448PrivateKey, _   = ed448.GenerateKey(rand.Reader)
+	es256                   = jwt.NewES256(jwt.ECDSAPrivateKey(es256PrivateKey))
+	otherES256PrivateKey, _ = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	otherES256              = jwt.NewES256(jwt.ECDSAPrivateKey(otherES256PrivateKey))
+	ps256                   = jwt.NewPS256(jwt.RSAPrivateKey(rsaPrivateKey))
+	ed25519                 = jwt.NewEd25519(jwt.Ed25519PrivateKey(ed25519PrivateKey))
+	es256k                  = jwt.NewES256K(jwt.ECDSAPrivateKey(es256kPrivateKey))
+	ed448Alg                = jwt.NewEd448(jwt.Ed448PrivateKey(ed448PrivateKey))
+)
+
+func TestNewProof(t *testing.T) {
+	testCases := []struct {
+		name string
+		alg  jwt.Algorithm
+		err  error
+	}{
+		{"ES256", es256, nil},
+		{"PS256", ps256, nil},
+		{"EdDSA", ed25519, nil},
+		{"ES256K", es256k, nil},
+		{"Ed448", ed448Alg, nil},
+		{"HS256", jwt.NewHS256([]byte("secret")), dpop.ErrUnsupportedAlg},
+		{"none", jwt.None(), dpop.ErrUnsupportedAlg},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			proof, err := dpop.NewProof(tc.alg, "GET", "https://rs.example.com/resource", dpop.AccessToken("token"))
+			if want, got := tc.err, err; !internal.ErrorIs(got, want) {
+				t.Fatalf("dpop.NewProof err mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+			if err != nil {
+				return
+			}
+			var pf dpop.Proof
+			hd, err := new(dpop.Verifier).Verify(proof, "GET", "https://rs.example.com/resource", &pf)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want, got := dpop.Type, hd.Type; got != want {
+				t.Errorf(`"typ" mismatch (-want +got):\n%s`, cmp.Diff(want, got))
+			}
+			if hd.JSONWebKey == nil {
+				t.Fatal(`"jwk" header is missing`)
+			}
+			if want, got := dpop.AccessTokenHash("token"), pf.AccessTokenHash; got != want {
+				t.Errorf(`"ath" mismatch (-want +got):\n%s`, cmp.Diff(want, got))
+			}
+			if pf.JWTID == "" || pf.IssuedAt == nil {
+				t.Errorf(`"jti" or "iat" claims are missing`)
+			}
+		})
+	}
+}
+
+func TestAccessTokenHash(t *testing.T) {
+	// Example from the RFC 9449, section 4.3.
+	var (
+		token = "Kz~8mXK1EalYznwH-LC-1fBAo.4Ljp~zsPE_NeO.gxU"
+		ath   = "fUHyO2r2Z3DZ53EsNrWBb0xWXoaNy59IiKCAqksmQEo"
+	)
+	if want, got := ath, dpop.AccessTokenHash(token); got != want {
+		t.Errorf("dpop.AccessTokenHash mismatch (-want +got):\n%s", cmp.Diff(want, got))
+	}
+}
@@ -0,0 +1,230 @@
+package dpop
+
+import (
+	"bytes"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gbrlsnchs/jwt/v3"
+	"github.com/gbrlsnchs/jwt/v3/internal"
+	"github.com/gbrlsnchs/jwt/v3/internal/pubalg"
+	"github.com/gbrlsnchs/jwt/v3/jwtutil"
+)
+
+const defaultMaxAge = time.Minute
+
+// privateJWKMembers are the JWK members holding private or symmetric key material,
+// as per the RFC 7518 and the RFC 8037.
+var privateJWKMembers = []string{"d", "p", "q", "dp", "dq", "qi", "oth", "k"}
+
+var (
+	// ErrMissingJWK is the error for a proof without a "jwk" header.
+	ErrMissingJWK = internal.NewError(`dpop: "jwk" header is missing`)
+	// ErrPrivateJWK is the error for a proof whose "jwk" header contains a private key.
+	ErrPrivateJWK = internal.NewError(`dpop: "jwk" header contains a private key`)
+	// ErrHTMValidation is the error for an invalid "htm" claim.
+	ErrHTMValidation = internal.NewError("dpop: htm claim is invalid")
+	// ErrHTUValidation is the error for an invalid "htu" claim.
+	ErrHTUValidation = internal.NewError("dpop: htu claim is invalid")
+	// ErrAthValidation is the error for an invalid "ath" claim.
+	ErrAthValidation = internal.NewError("dpop: ath claim is invalid")
+	// ErrNonceValidation is the error for an invalid "nonce" claim.
+	ErrNonceValidation = internal.NewError("dpop: nonce claim is invalid")
+	// ErrJKTValidation is the error for when a proof's key doesn't match the expected thumbprint.
+	ErrJKTValidation = internal.NewError("dpop: key thumbprint mismatch")
+	// ErrStaleProof is the error for a proof issued outside the acceptable time window.
+	ErrStaleProof = internal.NewError("dpop: proof is not fresh")
+)
+
+// VerifyOption is a functional option for verifying proofs.
+// It is run after the proof's signature and mandatory claims are verified.
+type VerifyOption func(hd *jwt.Header, pf *Proof) error
+
+// ValidateAccessToken checks a proof is bound to an access token and to the key it was issued to.
+// The "ath" claim must be the hash of token and the proof's key thumbprint must be jkt,
+// which is the "jkt" member of the access token's "cnf" claim.
+func ValidateAccessToken(token, jkt string) VerifyOption {
+	return func(hd *jwt.Header, pf *Proof) error {
+		if pf.AccessTokenHash != AccessTokenHash(token) {
+			return ErrAthValidation
+		}
+		return ValidateThumbprint(jkt)(hd, pf)
+	}
+}
+
+// ValidateThumbprint checks the proof's key thumbprint is jkt.
+func ValidateThumbprint(jkt string) VerifyOption {
+	return func(hd *jwt.Header, _ *Proof) error {
+		thumb, err := Thumbprint(hd.JSONWebKey)
+		if err != nil {
+			return err
+		}
+		if thumb != jkt {
+			return ErrJKTValidation
+		}
+		return nil
+	}
+}
+
+// ValidateNonce checks the "nonce" claim is the one provided by the server.
+func ValidateNonce(nonce string) VerifyOption {
+	return func(_ *jwt.Header, pf *Proof) error {
+		if pf.Nonce != nonce {
+			return ErrNonceValidation
+		}
+		return nil
+	}
+}
+
+// Verifier verifies DPoP proofs.
+type Verifier struct {
+	// MaxAge is for how long after its "iat" claim a proof is accepted. Defaults to 1 minute.
+	MaxAge time.Duration
+	// Leeway is the tolerated clock skew between client and server.
+	Leeway time.Duration
+	// Cache records the "jti" of accepted proofs so they can only be used once.
+	// When nil, replays are not detected.
+	Cache jwtutil.ReplayCache
+	// Now returns the current time. Defaults to time.Now.
+	Now func() time.Time
+}
+
+// Verify verifies proof was created for an HTTP request with method and uri and decodes it into pf.
+// The signature is verified with the public key embedded in the proof's header.
+func (v *Verifier) Verify(proof []byte, method, uri string, pf *Proof, opts ...VerifyOption) (jwt.Header, error) {
+	now := time.Now()
+	if v.Now != nil {
+		now = v.Now()
+	}
+	maxAge := v.MaxAge
+	if maxAge == 0 {
+		maxAge = defaultMaxAge
+	}
+	htu, err := normalizeURI(uri)
+	if err != nil {
+		return jwt.Header{}, err
+	}
+	rv := &jwtutil.Resolver{New: func(hd jwt.Header) (jwt.Algorithm, error) {
+		if hd.JSONWebKey == nil {
+			return nil, ErrMissingJWK
+		}
+		if err := validatePublicJWK(proof); err != nil {
+			return nil, err
+		}
+		alg, err := pubalg.FromJWK(hd.Algorithm, hd.JSONWebKey)
+		if internal.ErrorIs(err, pubalg.ErrUnsupported) {
+			return nil, ErrUnsupportedAlg
+		}
+		return alg, err
+	}}
+	vds := []jwt.Validator{
+		freshnessValidator(now, maxAge, v.Leeway),
+		func(_ *jwt.Payload) error {
+			if pf.HTTPMethod != method {
+				return ErrHTMValidation
+			}
+			if got, err := normalizeURI(pf.HTTPURI); err != nil || got != htu {
+				return ErrHTUValidation
+			}
+			return nil
+		},
+	}
+	hd, err := jwt.Verify(proof, rv, pf,
+		jwt.ValidateType(Type),
+		jwt.ValidatePayload(&pf.Payload, vds...),
+	)
+	if err != nil {
+		return hd, err
+	}
+	for _, opt := range opts {
+		if err = opt(&hd, pf); err != nil {
+			return hd, err
+		}
+	}
+	// Only record the "jti" once everything else has passed, so rejected proofs can't burn it.
+	if v.Cache != nil {
+		if err = jwtutil.ReplayValidator(v.Cache, now, maxAge+2*v.Leeway)(&pf.Payload); err != nil {
+			return hd, err
+		}
+	}
+	return hd, nil
+}
+
+// validatePublicJWK checks the "jwk" header of proof has no private key members,
+// as per the RFC 9449, section 4.3. Since jwt.JWK only decodes public members,
+// the header is decoded again for that.
+func validatePublicJWK(proof []byte) error {
+	var hd struct {
+		JWK map[string]interface{} `json:"jwk"`
+	}
+	if err := internal.Decode(proof[:bytes.IndexByte(proof, '.')], &hd); err != nil {
+		return err
+	}
+	for _, name := range privateJWKMembers {
+		if _, ok := hd.JWK[name]; ok {
+			return ErrPrivateJWK
+		}
+	}
+	return nil
+}
+
+func freshnessValidator(now time.Time, maxAge, leeway time.Duration) jwt.Validator {
+	return func(pl *jwt.Payload) error {
+		if pl.JWTID == "" {
+			return jwt.ErrJtiValidation
+		}
+		if pl.IssuedAt == nil {
+			return jwt.ErrIatValidation
+		}
+		if pl.IssuedAt.After(now.Add(leeway)) || pl.IssuedAt.Before(now.Add(-maxAge-leeway)) {
+			return ErrStaleProof
+		}
+		return nil
+	}
+}
+
+// normalizeURI applies syntax-based and scheme-based normalization to uri,
+// as per the RFC 3986, and strips its query and fragment.
+func normalizeURI(uri string) (string, error) {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return "", err
+	}
+	scheme := strings.ToLower(u.Scheme)
+	host := strings.ToLower(u.Hostname())
+	if strings.Contains(host, ":") {
+		host = "[" + host + "]"
+	}
+	if port := u.Port(); port != "" && !(scheme == "http" && port == "80") && !(scheme == "https" && port == "443") {
+		host += ":" + port
+	}
+	p := removeDotSegments(u.Path)
+	if p == "" {
+		p = "/"
+	}
+	return (&url.URL{Scheme: scheme, Host: host, Path: p}).String(), nil
+}
+
+// removeDotSegments implements the algorithm from the RFC 3986, section 5.2.4.
+func removeDotSegments(p string) string {
+	var out []string
+	segs := strings.Split(p, "/")
+	for i, seg := range segs {
+		switch seg {
+		case ".":
+		case "..":
+			if len(out) > 1 {
+				out = out[:len(out)-1]
+			}
+		default:
+			out = append(out, seg)
+			continue
+		}
+		// A trailing dot segment still refers to a directory.
+		if i == len(segs)-1 {
+			out = append(out, "")
+		}
+	}
+	return strings.Join(out, "/")
+}
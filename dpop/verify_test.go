@@ -0,0 +1,259 @@
+package dpop_test
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/gbrlsnchs/jwt/v3"
+	"github.com/gbrlsnchs/jwt/v3/dpop"
+	"github.com/gbrlsnchs/jwt/v3/internal"
+	"github.com/gbrlsnchs/jwt/v3/jwtutil"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestVerify(t *testing.T) {
+	now := time.Now()
+	jwk, err := jwt.NewJWK(es256PrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	jkt, err := dpop.Thumbprint(jwk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cache := jwtutil.NewMemoryCache()
+	testCases := []struct {
+		name       string
+		alg        jwt.Algorithm
+		htm        string
+		htu        string
+		method     string
+		uri        string
+		proofOpts  []dpop.ProofOption
+		verifyOpts []dpop.VerifyOption
+		verifier   *dpop.Verifier
+		replay     bool
+		err        error
+	}{
+		{
+			name:     "valid",
+			alg:      es256,
+			htm:      "POST",
+			htu:      "https://server.example.com/token",
+			method:   "POST",
+			uri:      "https://server.example.com/token",
+			verifier: &dpop.Verifier{},
+			err:      nil,
+		},
+		{
+			name:     "normalized URI",
+			alg:      es256,
+			htm:      "POST",
+			htu:      "https://server.example.com/token",
+			method:   "POST",
+			uri:      "HTTPS://Server.Example.COM:443/./token?foo=bar#baz",
+			verifier: &dpop.Verifier{},
+			err:      nil,
+		},
+		{
+			name:     "wrong method",
+			alg:      es256,
+			htm:      "POST",
+			htu:      "https://server.example.com/token",
+			method:   "GET",
+			uri:      "https://server.example.com/token",
+			verifier: &dpop.Verifier{},
+			err:      dpop.ErrHTMValidation,
+		},
+		{
+			name:     "wrong URI",
+			alg:      es256,
+			htm:      "POST",
+			htu:      "https://server.example.com/token",
+			method:   "POST",
+			uri:      "https://server.example.com/authorize",
+			verifier: &dpop.Verifier{},
+			err:      dpop.ErrHTUValidation,
+		},
+		{
+			name:      "stale",
+			alg:       es256,
+			htm:       "POST",
+			htu:       "https://server.example.com/token",
+			method:    "POST",
+			uri:       "https://server.example.com/token",
+			proofOpts: []dpop.ProofOption{dpop.IssuedAt(now.Add(-time.Hour))},
+			verifier:  &dpop.Verifier{},
+			err:       dpop.ErrStaleProof,
+		},
+		{
+			name:      "issued in the future",
+			alg:       es256,
+			htm:       "POST",
+			htu:       "https://server.example.com/token",
+			method:    "POST",
+			uri:       "https://server.example.com/token",
+			proofOpts: []dpop.ProofOption{dpop.IssuedAt(now.Add(time.Minute))},
+			verifier:  &dpop.Verifier{Leeway: 5 * time.Second},
+			err:       dpop.ErrStaleProof,
+		},
+		{
+			name:      "replay",
+			alg:       es256,
+			htm:       "POST",
+			htu:       "https://server.example.com/token",
+			method:    "POST",
+			uri:       "https://server.example.com/token",
+			proofOpts: []dpop.ProofOption{dpop.ID("foobar")},
+			verifier:  &dpop.Verifier{Cache: cache},
+			replay:    true,
+			err:       jwtutil.ErrReplay,
+		},
+		{
+			name:       "bound access token",
+			alg:        es256,
+			htm:        "GET",
+			htu:        "https://rs.example.com/resource",
+			method:     "GET",
+			uri:        "https://rs.example.com/resource",
+			proofOpts:  []dpop.ProofOption{dpop.AccessToken("token")},
+			verifyOpts: []dpop.VerifyOption{dpop.ValidateAccessToken("token", jkt)},
+			verifier:   &dpop.Verifier{},
+			err:        nil,
+		},
+		{
+			name:       "wrong access token",
+			alg:        es256,
+			htm:        "GET",
+			htu:        "https://rs.example.com/resource",
+			method:     "GET",
+			uri:        "https://rs.example.com/resource",
+			proofOpts:  []dpop.ProofOption{dpop.AccessToken("other")},
+			verifyOpts: []dpop.VerifyOption{dpop.ValidateAccessToken("token", jkt)},
+			verifier:   &dpop.Verifier{},
+			err:        dpop.ErrAthValidation,
+		},
+		{
+			name:       "wrong key",
+			alg:        otherES256,
+			htm:        "GET",
+			htu:        "https://rs.example.com/resource",
+			method:     "GET",
+			uri:        "https://rs.example.com/resource",
+			proofOpts:  []dpop.ProofOption{dpop.AccessToken("token")},
+			verifyOpts: []dpop.VerifyOption{dpop.ValidateAccessToken("token", jkt)},
+			verifier:   &dpop.Verifier{},
+			err:        dpop.ErrJKTValidation,
+		},
+		{
+			name:       "nonce",
+			alg:        ed25519,
+			htm:        "POST",
+			htu:        "https://server.example.com/token",
+			method:     "POST",
+			uri:        "https://server.example.com/token",
+			proofOpts:  []dpop.ProofOption{dpop.Nonce("nonce")},
+			verifyOpts: []dpop.VerifyOption{dpop.ValidateNonce("other")},
+			verifier:   &dpop.Verifier{},
+			err:        dpop.ErrNonceValidation,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			proof, err := dpop.NewProof(tc.alg, tc.htm, tc.htu, tc.proofOpts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var pf dpop.Proof
+			if tc.replay {
+				if _, err = tc.verifier.Verify(proof, tc.method, tc.uri, &pf, tc.verifyOpts...); err != nil {
+					t.Fatal(err)
+				}
+			}
+			_, err = tc.verifier.Verify(proof, tc.method, tc.uri, &pf, tc.verifyOpts...)
+			if want, got := tc.err, err; !internal.ErrorIs(got, want) {
+				t.Errorf("dpop.Verifier.Verify err mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+		})
+	}
+
+	t.Run("symmetric algorithm", func(t *testing.T) {
+		hs256 := jwt.NewHS256([]byte("secret"))
+		token, err := jwt.Sign(dpop.Proof{}, hs256, jwt.Type(dpop.Type), jwt.JSONWebKey(jwk))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var pf dpop.Proof
+		_, err = new(dpop.Verifier).Verify(token, "POST", "https://server.example.com/token", &pf)
+		if want, got := dpop.ErrUnsupportedAlg, err; !internal.ErrorIs(got, want) {
+			t.Errorf("dpop.Verifier.Verify err mismatch (-want +got):\n%s", cmp.Diff(want, got))
+		}
+	})
+
+	t.Run("private key", func(t *testing.T) {
+		proof, err := dpop.NewProof(es256, "POST", "https://server.example.com/token")
+		if err != nil {
+			t.Fatal(err)
+		}
+		parts := bytes.Split(proof, []byte("."))
+		var hd map[string]interface{}
+		if err = internal.Decode(parts[0], &hd); err != nil {
+			t.Fatal(err)
+		}
+		enc := base64.RawURLEncoding
+		hd["jwk"].(map[string]interface{})["d"] = enc.EncodeToString(es256PrivateKey.D.Bytes())
+		b, err := json.Marshal(hd)
+		if err != nil {
+			t.Fatal(err)
+		}
+		headerPayload := []byte(enc.EncodeToString(b) + "." + string(parts[1]))
+		sig, err := es256.Sign(headerPayload)
+		if err != nil {
+			t.Fatal(err)
+		}
+		token := append(append(headerPayload, '.'), enc.EncodeToString(sig)...)
+		var pf dpop.Proof
+		_, err = new(dpop.Verifier).Verify(token, "POST", "https://server.example.com/token", &pf)
+		if want, got := dpop.ErrPrivateJWK, err; !internal.ErrorIs(got, want) {
+			t.Errorf("dpop.Verifier.Verify err mismatch (-want +got):\n%s", cmp.Diff(want, got))
+		}
+	})
+
+	t.Run("weak key", func(t *testing.T) {
+		priv, err := rsa.GenerateKey(rand.Reader, 1024)
+		if err != nil {
+			t.Fatal(err)
+		}
+		proof, err := dpop.NewProof(jwt.NewPS256(jwt.RSAPrivateKey(priv)), "POST", "https://server.example.com/token")
+		if err != nil {
+			t.Fatal(err)
+		}
+		var pf dpop.Proof
+		_, err = new(dpop.Verifier).Verify(proof, "POST", "https://server.example.com/token", &pf)
+		if want, got := jwt.ErrRSAKeyTooShort, err; !internal.ErrorIs(got, want) {
+			t.Errorf("dpop.Verifier.Verify err mismatch (-want +got):\n%s", cmp.Diff(want, got))
+		}
+	})
+
+	t.Run("rejected proof keeps jti", func(t *testing.T) {
+		proof, err := dpop.NewProof(es256, "POST", "https://server.example.com/token", dpop.Nonce("nonce"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		v := &dpop.Verifier{Cache: jwtutil.NewMemoryCache()}
+		var pf dpop.Proof
+		_, err = v.Verify(proof, "POST", "https://server.example.com/token", &pf, dpop.ValidateNonce("other"))
+		if want, got := dpop.ErrNonceValidation, err; !internal.ErrorIs(got, want) {
+			t.Fatalf("dpop.Verifier.Verify err mismatch (-want +got):\n%s", cmp.Diff(want, got))
+		}
+		_, err = v.Verify(proof, "POST", "https://server.example.com/token", &pf, dpop.ValidateNonce("nonce"))
+		if want, got := error(nil), err; !internal.ErrorIs(got, want) {
+			t.Errorf("dpop.Verifier.Verify err mismatch (-want +got):\n%s", cmp.Diff(want, got))
+		}
+	})
+}
@@ -0,0 +1,34 @@
+// +build go1.24
+
+package jwt
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"encoding/asn1"
+	"math/big"
+)
+
+// deterministicCurve reports whether crypto/ecdsa signs deterministically using curve.
+func deterministicCurve(curve elliptic.Curve) bool {
+	switch curve {
+	case elliptic.P224(), elliptic.P256(), elliptic.P384(), elliptic.P521():
+		return true
+	}
+	return false
+}
+
+// signDeterministic signs sum with priv as per the RFC 6979, which crypto/ecdsa
+// does since Go 1.24 when no source of randomness is given.
+func signDeterministic(priv *ecdsa.PrivateKey, sha crypto.Hash, sum []byte) (r, s *big.Int, err error) {
+	der, err := priv.Sign(nil, sum, sha)
+	if err != nil {
+		return nil, nil, err
+	}
+	var sig struct{ R, S *big.Int }
+	if _, err = asn1.Unmarshal(der, &sig); err != nil {
+		return nil, nil, err
+	}
+	return sig.R, sig.S, nil
+}
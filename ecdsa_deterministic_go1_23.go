@@ -0,0 +1,16 @@
+// +build !go1.24
+
+package jwt
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"math/big"
+)
+
+func deterministicCurve(elliptic.Curve) bool { return false }
+
+func signDeterministic(*ecdsa.PrivateKey, crypto.Hash, []byte) (r, s *big.Int, err error) {
+	return nil, nil, ErrECDSADeterministic
+}
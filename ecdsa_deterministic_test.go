@@ -0,0 +1,53 @@
+// +build go1.24
+
+package jwt_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"encoding/base64"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/gbrlsnchs/jwt/v3"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestECDSADeterministic(t *testing.T) {
+	// Test vectors from the RFC 6979, section A.2.5.
+	d, _ := new(big.Int).SetString("C9AFA9D845BA75166B5C215767B1D6934E50C3DB36E89B127B8A622B120F6721", 16)
+	priv := &ecdsa.PrivateKey{D: d}
+	priv.Curve = elliptic.P256()
+	priv.X, priv.Y = priv.Curve.ScalarBaseMult(d.Bytes())
+	testCases := []struct {
+		msg  string
+		r, s string
+	}{
+		{
+			msg: "sample",
+			r:   "efd48b2aacb6a8fd1140dd9cd45e81d69d2c877b56aaf991c34d0ea84eaf3716",
+			s:   "f7cb1c942d657c41d436c7a1b6e29f65f3e900dbb9aff4064dc4ab2f843acda8",
+		},
+		{
+			msg: "test",
+			r:   "f1abb023518351cd71d881567b1ea663ed3efcf6c5132b354f28d3b0b7d38367",
+			s:   "019f4113742a2b14bd25926b49c649155f267e60d3814b4c0cc84250e46f0083",
+		},
+	}
+	es256 := jwt.NewES256(jwt.ECDSAPrivateKey(priv), jwt.ECDSADeterministic())
+	for _, tc := range testCases {
+		t.Run(tc.msg, func(t *testing.T) {
+			sig, err := es256.Sign([]byte(tc.msg))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want, got := tc.r+tc.s, hex.EncodeToString(sig); got != want {
+				t.Errorf("jwt.ECDSASHA.Sign mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+			if err = es256.Verify([]byte(tc.msg), []byte(base64.RawURLEncoding.EncodeToString(sig))); err != nil {
+				t.Errorf("jwt.ECDSASHA.Verify err: %v", err)
+			}
+		})
+	}
+}
@@ -3,7 +3,9 @@ package jwt
 import (
 	"crypto"
 	"crypto/ecdsa"
+	"crypto/elliptic"
 	"crypto/rand"
+	"io"
 	"math/big"
 
 	"github.com/gbrlsnchs/jwt/v3/internal"
@@ -14,6 +16,12 @@ var (
 	ErrECDSANilPrivKey = internal.NewError("jwt: ECDSA private key is nil")
 	// ErrECDSANilPubKey is the error for trying to verify a JWT with a nil public key.
 	ErrECDSANilPubKey = internal.NewError("jwt: ECDSA public key is nil")
+	// ErrECDSACurve is the error for an ECDSA key whose curve doesn't match the algorithm, as per the RFC 7518.
+	ErrECDSACurve = internal.NewError("jwt: ECDSA key curve mismatch")
+	// ErrECDSAKeyMismatch is the error for an ECDSA private key that doesn't match the public key it is set with.
+	ErrECDSAKeyMismatch = internal.NewError("jwt: ECDSA private key doesn't match public key")
+	// ErrECDSADeterministic is the error for signing deterministically with a Go version or curve that doesn't support it.
+	ErrECDSADeterministic = internal.NewError("jwt: deterministic ECDSA signatures are not supported")
 	// ErrECDSAVerification is the error for an invalid ECDSA signature.
 	ErrECDSAVerification = internal.NewError("jwt: ECDSA verification failed")
 
@@ -34,6 +42,26 @@ func ECDSAPublicKey(pub *ecdsa.PublicKey) func(*ECDSASHA) {
 	}
 }
 
+// ECDSARand is an option to set the source of randomness for signing with ECDSA-SHA,
+// which defaults to crypto/rand.Reader. Signatures aren't reproducible even with a fixed r,
+// since crypto/ecdsa mixes it with the private key, and, as of Go 1.26, crypto/ecdsa ignores r
+// unless GODEBUG=cryptocustomrand=1 is set. ES256K and ECDSADeterministic signatures don't use it.
+func ECDSARand(r io.Reader) func(*ECDSASHA) {
+	return func(es *ECDSASHA) {
+		es.rand = r
+	}
+}
+
+// ECDSADeterministic is an option to generate nonces deterministically from the private key
+// and the message, as per the RFC 6979, so that signing the same message yields the same signature.
+// It requires Go 1.24 or later and a curve from crypto/elliptic, otherwise creating the algorithm
+// with a private key fails with ErrECDSADeterministic. ES256K signatures are always deterministic.
+func ECDSADeterministic() func(*ECDSASHA) {
+	return func(es *ECDSASHA) {
+		es.deterministic = true
+	}
+}
+
 func byteSize(bitSize int) int {
 	byteSize := bitSize / 8
 	if bitSize%8 > 0 {
@@ -49,11 +77,37 @@ type ECDSASHA struct {
 	pub  *ecdsa.PublicKey
 	sha  crypto.Hash
 	size int
+	lowS bool
+
+	rand          io.Reader
+	deterministic bool
 
 	pool *hashPool
 }
 
 func newECDSASHA(name string, opts []func(*ECDSASHA), sha crypto.Hash) *ECDSASHA {
+	es, err := initECDSASHA(name, opts, sha)
+	if err != nil {
+		panic(err)
+	}
+	return es
+}
+
+func newCheckedECDSASHA(name string, opts []func(*ECDSASHA), sha crypto.Hash, curve elliptic.Curve) (*ECDSASHA, error) {
+	es, err := initECDSASHA(name, opts, sha)
+	if err != nil {
+		return nil, err
+	}
+	if es.pub.Curve != curve || (es.priv != nil && es.priv.Curve != curve) {
+		return nil, ErrECDSACurve
+	}
+	if es.priv != nil && (es.priv.X.Cmp(es.pub.X) != 0 || es.priv.Y.Cmp(es.pub.Y) != 0) {
+		return nil, ErrECDSAKeyMismatch
+	}
+	return es, nil
+}
+
+func initECDSASHA(name string, opts []func(*ECDSASHA), sha crypto.Hash) (*ECDSASHA, error) {
 	es := ECDSASHA{
 		name: name,
 		sha:  sha,
@@ -66,12 +120,34 @@ func newECDSASHA(name string, opts []func(*ECDSASHA), sha crypto.Hash) *ECDSASHA
 	}
 	if es.pub == nil {
 		if es.priv == nil {
-			panic(ErrECDSANilPrivKey)
+			return nil, ErrECDSANilPrivKey
 		}
 		es.pub = &es.priv.PublicKey
 	}
+	if es.deterministic && es.priv != nil && es.priv.Curve != Secp256k1() && !deterministicCurve(es.priv.Curve) {
+		return nil, ErrECDSADeterministic
+	}
 	es.size = byteSize(es.pub.Params().BitSize) * 2
-	return &es
+	return &es, nil
+}
+
+func ecdsaSHAFactory(name string, fn func(...func(*ECDSASHA)) (*ECDSASHA, error)) AlgorithmFactory {
+	return func(key interface{}) (Algorithm, error) {
+		var opt func(*ECDSASHA)
+		switch key := key.(type) {
+		case *ecdsa.PrivateKey:
+			opt = ECDSAPrivateKey(key)
+		case *ecdsa.PublicKey:
+			opt = ECDSAPublicKey(key)
+		default:
+			return nil, internal.Errorf("jwt: %q: %w", name, ErrInvalidKey)
+		}
+		es, err := fn(opt)
+		if err != nil {
+			return nil, err // don't return a typed nil
+		}
+		return es, nil
+	}
 }
 
 // NewES256 creates a new algorithm using ECDSA and SHA-256.
@@ -89,11 +165,53 @@ func NewES512(opts ...func(*ECDSASHA)) *ECDSASHA {
 	return newECDSASHA("ES512", opts, crypto.SHA512)
 }
 
+// NewES256K creates a new algorithm using ECDSA over secp256k1 and SHA-256, as per the RFC 8812.
+// Signatures are normalized to their low-S form.
+func NewES256K(opts ...func(*ECDSASHA)) *ECDSASHA {
+	es := newECDSASHA("ES256K", opts, crypto.SHA256)
+	es.lowS = true
+	return es
+}
+
+// NewCheckedES256 is like NewES256, but returns an error instead of panicking
+// and rejects keys not using the P-256 curve.
+func NewCheckedES256(opts ...func(*ECDSASHA)) (*ECDSASHA, error) {
+	return newCheckedECDSASHA("ES256", opts, crypto.SHA256, elliptic.P256())
+}
+
+// NewCheckedES384 is like NewES384, but returns an error instead of panicking
+// and rejects keys not using the P-384 curve.
+func NewCheckedES384(opts ...func(*ECDSASHA)) (*ECDSASHA, error) {
+	return newCheckedECDSASHA("ES384", opts, crypto.SHA384, elliptic.P384())
+}
+
+// NewCheckedES512 is like NewES512, but returns an error instead of panicking
+// and rejects keys not using the P-521 curve.
+func NewCheckedES512(opts ...func(*ECDSASHA)) (*ECDSASHA, error) {
+	return newCheckedECDSASHA("ES512", opts, crypto.SHA512, elliptic.P521())
+}
+
+// NewCheckedES256K is like NewES256K, but returns an error instead of panicking
+// and rejects keys not using the secp256k1 curve.
+func NewCheckedES256K(opts ...func(*ECDSASHA)) (*ECDSASHA, error) {
+	es, err := newCheckedECDSASHA("ES256K", opts, crypto.SHA256, Secp256k1())
+	if err != nil {
+		return nil, err
+	}
+	es.lowS = true
+	return es, nil
+}
+
 // Name returns the algorithm's name.
 func (es *ECDSASHA) Name() string {
 	return es.name
 }
 
+// Public returns the ECDSA public key.
+func (es *ECDSASHA) Public() crypto.PublicKey {
+	return es.pub
+}
+
 // Sign signs headerPayload using the ECDSA-SHA algorithm.
 func (es *ECDSASHA) Sign(headerPayload []byte) ([]byte, error) {
 	if es.priv == nil {
@@ -109,24 +227,30 @@ func (es *ECDSASHA) Size() int {
 
 // Verify verifies a signature based on headerPayload using ECDSA-SHA.
 func (es *ECDSASHA) Verify(headerPayload, sig []byte) (err error) {
+	sc := scratchPool.Get().(*verifyScratch)
+	defer scratchPool.Put(sc)
+	if sc.sig, err = decodeAppend(sc.sig[:0], sig); err != nil {
+		return err
+	}
+	return es.verify(headerPayload, sc.sig, sc)
+}
+
+func (es *ECDSASHA) verify(headerPayload, sig []byte, sc *verifyScratch) error {
 	if es.pub == nil {
 		return ErrECDSANilPubKey
 	}
-	if sig, err = internal.DecodeToBytes(sig); err != nil {
-		return err
-	}
 	byteSize := byteSize(es.pub.Params().BitSize)
 	if len(sig) != byteSize*2 {
 		return ErrECDSAVerification
 	}
 
-	r := big.NewInt(0).SetBytes(sig[:byteSize])
-	s := big.NewInt(0).SetBytes(sig[byteSize:])
-	sum, err := es.pool.sign(headerPayload)
-	if err != nil {
+	sc.r.SetBytes(sig[:byteSize])
+	sc.s.SetBytes(sig[byteSize:])
+	var err error
+	if sc.sum, err = es.pool.sum(sc.sum[:0], headerPayload); err != nil {
 		return err
 	}
-	if !ecdsa.Verify(es.pub, sum, r, s) {
+	if !ecdsa.Verify(es.pub, sc.sum, &sc.r, &sc.s) {
 		return ErrECDSAVerification
 	}
 	return nil
@@ -137,10 +261,25 @@ func (es *ECDSASHA) sign(headerPayload []byte) ([]byte, error) {
 	if err != nil {
 		return nil, err
 	}
-	r, s, err := ecdsa.Sign(rand.Reader, es.priv, sum)
+	if es.priv.Curve == Secp256k1() {
+		return signSecp256k1(es.priv, sum), nil
+	}
+	var r, s *big.Int
+	if es.deterministic {
+		r, s, err = signDeterministic(es.priv, es.sha, sum)
+	} else {
+		rnd := es.rand
+		if rnd == nil {
+			rnd = rand.Reader
+		}
+		r, s, err = ecdsa.Sign(rnd, es.priv, sum)
+	}
 	if err != nil {
 		return nil, err
 	}
+	if n := es.priv.Params().N; es.lowS && s.Cmp(new(big.Int).Rsh(n, 1)) > 0 {
+		s.Sub(n, s)
+	}
 	byteSize := byteSize(es.priv.Params().BitSize)
 	rbytes := r.Bytes()
 	rsig := make([]byte, byteSize)
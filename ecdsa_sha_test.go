@@ -1,10 +1,11 @@
 package jwt_test
 
 import (
+	"bytes"
 	"crypto/ecdsa"
 	"crypto/elliptic"
 	"crypto/rand"
-
+	"math/big"
 	"testing"
 
 	"github.com/gbrlsnchs/jwt/v3"
@@ -22,6 +23,9 @@ var (
 	es512PrivateKey1, es512PublicKey1 = genECDSAKeys(elliptic.P521())
 	es512PrivateKey2, es512PublicKey2 = genECDSAKeys(elliptic.P521())
 
+	es256kPrivateKey1, es256kPublicKey1 = genECDSAKeys(jwt.Secp256k1())
+	es256kPrivateKey2, es256kPublicKey2 = genECDSAKeys(jwt.Secp256k1())
+
 	ecdsaTestCases = []testCase{
 		{
 			alg:       jwt.NewES256(jwt.ECDSAPrivateKey(es256PrivateKey1)),
@@ -203,6 +207,42 @@ var (
 			signErr:     nil,
 			verifyErr:   jwt.ErrECDSAVerification,
 		},
+		{
+			alg:       jwt.NewES256K(jwt.ECDSAPrivateKey(es256kPrivateKey1)),
+			payload:   tp,
+			verifyAlg: jwt.NewES256K(jwt.ECDSAPublicKey(es256kPublicKey1)),
+			wantHeader: jwt.Header{
+				Algorithm: "ES256K",
+				Type:      "JWT",
+			},
+			wantPayload: tp,
+			signErr:     nil,
+			verifyErr:   nil,
+		},
+		{
+			alg:       jwt.NewES256K(jwt.ECDSAPrivateKey(es256kPrivateKey1)),
+			payload:   tp,
+			verifyAlg: jwt.NewES256K(jwt.ECDSAPublicKey(es256kPublicKey2)),
+			wantHeader: jwt.Header{
+				Algorithm: "ES256K",
+				Type:      "JWT",
+			},
+			wantPayload: testPayload{},
+			signErr:     nil,
+			verifyErr:   jwt.ErrECDSAVerification,
+		},
+		{
+			alg:       jwt.NewES256K(jwt.ECDSAPrivateKey(es256kPrivateKey1)),
+			payload:   tp,
+			verifyAlg: jwt.NewES256(jwt.ECDSAPublicKey(es256PublicKey1)),
+			wantHeader: jwt.Header{
+				Algorithm: "ES256K",
+				Type:      "JWT",
+			},
+			wantPayload: testPayload{},
+			signErr:     nil,
+			verifyErr:   jwt.ErrECDSAVerification,
+		},
 	}
 )
 
@@ -224,6 +264,9 @@ func TestNewECDSASHA(t *testing.T) {
 		{jwt.NewES512, jwt.ECDSAPrivateKey(nil), jwt.ErrECDSANilPrivKey},
 		{jwt.NewES512, jwt.ECDSAPrivateKey(es512PrivateKey1), nil},
 		{jwt.NewES512, jwt.ECDSAPublicKey(es512PublicKey1), nil},
+		{jwt.NewES256K, nil, jwt.ErrECDSANilPrivKey},
+		{jwt.NewES256K, jwt.ECDSAPrivateKey(es256kPrivateKey1), nil},
+		{jwt.NewES256K, jwt.ECDSAPublicKey(es256kPublicKey1), nil},
 	}
 	for _, tc := range testCases {
 		funcName := funcName(tc.builder)
@@ -247,6 +290,102 @@ func TestNewECDSASHA(t *testing.T) {
 	}
 }
 
+func TestNewCheckedECDSASHA(t *testing.T) {
+	keys := func(priv *ecdsa.PrivateKey, pub *ecdsa.PublicKey) func(*jwt.ECDSASHA) {
+		return func(es *jwt.ECDSASHA) {
+			jwt.ECDSAPrivateKey(priv)(es)
+			jwt.ECDSAPublicKey(pub)(es)
+		}
+	}
+	testCases := []struct {
+		builder func(...func(*jwt.ECDSASHA)) (*jwt.ECDSASHA, error)
+		opts    func(*jwt.ECDSASHA)
+		err     error
+	}{
+		{jwt.NewCheckedES256, nil, jwt.ErrECDSANilPrivKey},
+		{jwt.NewCheckedES256, jwt.ECDSAPrivateKey(es256PrivateKey1), nil},
+		{jwt.NewCheckedES256, jwt.ECDSAPublicKey(es256PublicKey1), nil},
+		{jwt.NewCheckedES256, jwt.ECDSAPrivateKey(es512PrivateKey1), jwt.ErrECDSACurve},
+		{jwt.NewCheckedES384, nil, jwt.ErrECDSANilPrivKey},
+		{jwt.NewCheckedES384, jwt.ECDSAPrivateKey(es384PrivateKey1), nil},
+		{jwt.NewCheckedES384, jwt.ECDSAPublicKey(es384PublicKey1), nil},
+		{jwt.NewCheckedES384, jwt.ECDSAPrivateKey(es256PrivateKey1), jwt.ErrECDSACurve},
+		{jwt.NewCheckedES512, nil, jwt.ErrECDSANilPrivKey},
+		{jwt.NewCheckedES512, jwt.ECDSAPrivateKey(es512PrivateKey1), nil},
+		{jwt.NewCheckedES512, jwt.ECDSAPublicKey(es512PublicKey1), nil},
+		{jwt.NewCheckedES512, jwt.ECDSAPublicKey(es384PublicKey1), jwt.ErrECDSACurve},
+		{jwt.NewCheckedES256K, nil, jwt.ErrECDSANilPrivKey},
+		{jwt.NewCheckedES256K, jwt.ECDSAPrivateKey(es256kPrivateKey1), nil},
+		{jwt.NewCheckedES256K, jwt.ECDSAPublicKey(es256kPublicKey1), nil},
+		{jwt.NewCheckedES256K, jwt.ECDSAPrivateKey(es256PrivateKey1), jwt.ErrECDSACurve},
+		{jwt.NewCheckedES256, keys(es256PrivateKey1, es256PublicKey1), nil},
+		{jwt.NewCheckedES256, keys(es512PrivateKey1, es256PublicKey1), jwt.ErrECDSACurve},
+		{jwt.NewCheckedES256, keys(es256PrivateKey1, es256PublicKey2), jwt.ErrECDSAKeyMismatch},
+	}
+	for _, tc := range testCases {
+		funcName := funcName(tc.builder)
+		t.Run(funcName, func(t *testing.T) {
+			_, err := tc.builder(tc.opts)
+			if want, got := tc.err, err; !internal.ErrorIs(got, want) {
+				t.Errorf("jwt.%s err mismatch (-want +got):\n%s", funcName, cmp.Diff(want, got))
+			}
+		})
+	}
+}
+
+func TestES256KLowS(t *testing.T) {
+	es256k := jwt.NewES256K(jwt.ECDSAPrivateKey(es256kPrivateKey1))
+	halfN := new(big.Int).Rsh(jwt.Secp256k1().Params().N, 1)
+	for i := 0; i < 32; i++ {
+		sig, err := es256k.Sign([]byte("header.payload"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if s := new(big.Int).SetBytes(sig[32:]); s.Cmp(halfN) > 0 {
+			t.Fatalf("jwt.ES256K.Sign returned a high S value: %x", s)
+		}
+	}
+}
+
+func TestES256KDeterministic(t *testing.T) {
+	var sigs [2][]byte
+	for i := range sigs {
+		sig, err := jwt.NewES256K(jwt.ECDSAPrivateKey(es256kPrivateKey1)).Sign([]byte("header.payload"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		sigs[i] = sig
+	}
+	if want, got := sigs[0], sigs[1]; !bytes.Equal(got, want) {
+		t.Errorf("jwt.ES256K signatures mismatch (-want +got):\n%s", cmp.Diff(want, got))
+	}
+}
+
+func TestECDSADeterministicUnsupported(t *testing.T) {
+	// crypto/ecdsa only signs deterministically using the curves of crypto/elliptic,
+	// not their generic implementation.
+	priv := *es256PrivateKey1
+	priv.Curve = elliptic.P256().Params()
+	testCases := []struct {
+		name string
+		new  func(...func(*jwt.ECDSASHA)) (*jwt.ECDSASHA, error)
+		opt  func(*jwt.ECDSASHA)
+		err  error
+	}{
+		{"unsupported curve", jwt.NewCheckedES256, jwt.ECDSAPrivateKey(&priv), jwt.ErrECDSADeterministic},
+		{"public key", jwt.NewCheckedES256, jwt.ECDSAPublicKey(es256PublicKey1), nil},
+		{"ES256K", jwt.NewCheckedES256K, jwt.ECDSAPrivateKey(es256kPrivateKey1), nil},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := tc.new(tc.opt, jwt.ECDSADeterministic())
+			if want, got := tc.err, err; !internal.ErrorIs(got, want) {
+				t.Errorf("jwt.NewChecked* err mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+		})
+	}
+}
+
 func genECDSAKeys(c elliptic.Curve) (*ecdsa.PrivateKey, *ecdsa.PublicKey) {
 	priv, err := ecdsa.GenerateKey(c, rand.Reader)
 	if err != nil {
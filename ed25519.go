@@ -3,6 +3,8 @@
 package jwt
 
 import (
+	"bytes"
+	"crypto"
 	"crypto/ed25519"
 
 	"github.com/gbrlsnchs/jwt/v3/internal"
@@ -13,6 +15,10 @@ var (
 	ErrEd25519NilPrivKey = internal.NewError("jwt: Ed25519 private key is nil")
 	// ErrEd25519NilPubKey is the error for trying to verify a JWT with a nil public key.
 	ErrEd25519NilPubKey = internal.NewError("jwt: Ed25519 public key is nil")
+	// ErrEd25519KeySize is the error for an Ed25519 key of invalid size.
+	ErrEd25519KeySize = internal.NewError("jwt: Ed25519 key has an invalid size")
+	// ErrEd25519KeyMismatch is the error for an Ed25519 private key that doesn't match the public key it is set with.
+	ErrEd25519KeyMismatch = internal.NewError("jwt: Ed25519 private key doesn't match public key")
 	// ErrEd25519Verification is the error for when verification with Ed25519 fails.
 	ErrEd25519Verification = internal.NewError("jwt: Ed25519 verification failed")
 
@@ -41,6 +47,30 @@ type Ed25519 struct {
 
 // NewEd25519 creates a new algorithm using EdDSA and SHA-512.
 func NewEd25519(opts ...func(*Ed25519)) *Ed25519 {
+	ed, err := initEd25519(opts)
+	if err != nil {
+		panic(err)
+	}
+	return ed
+}
+
+// NewCheckedEd25519 is like NewEd25519, but returns an error instead of panicking
+// and rejects keys of invalid size or not matching each other.
+func NewCheckedEd25519(opts ...func(*Ed25519)) (*Ed25519, error) {
+	ed, err := initEd25519(opts)
+	if err != nil {
+		return nil, err
+	}
+	if ed.priv != nil && len(ed.priv) != ed25519.PrivateKeySize || len(ed.pub) != ed25519.PublicKeySize {
+		return nil, ErrEd25519KeySize
+	}
+	if ed.priv != nil && !bytes.Equal(ed.priv.Public().(ed25519.PublicKey), ed.pub) {
+		return nil, ErrEd25519KeyMismatch
+	}
+	return ed, nil
+}
+
+func initEd25519(opts []func(*Ed25519)) (*Ed25519, error) {
 	var ed Ed25519
 	for _, opt := range opts {
 		if opt != nil {
@@ -49,11 +79,31 @@ func NewEd25519(opts ...func(*Ed25519)) *Ed25519 {
 	}
 	if ed.pub == nil {
 		if len(ed.priv) == 0 {
-			panic(ErrEd25519NilPrivKey)
+			return nil, ErrEd25519NilPrivKey
+		}
+		if len(ed.priv) != ed25519.PrivateKeySize {
+			return nil, ErrEd25519KeySize
 		}
 		ed.pub = ed.priv.Public().(ed25519.PublicKey)
 	}
-	return &ed
+	return &ed, nil
+}
+
+func ed25519Factory(key interface{}) (Algorithm, error) {
+	var opt func(*Ed25519)
+	switch key := key.(type) {
+	case ed25519.PrivateKey:
+		opt = Ed25519PrivateKey(key)
+	case ed25519.PublicKey:
+		opt = Ed25519PublicKey(key)
+	default:
+		return nil, internal.Errorf("jwt: %q: %w", "EdDSA", ErrInvalidKey)
+	}
+	ed, err := NewCheckedEd25519(opt)
+	if err != nil {
+		return nil, err // don't return a typed nil
+	}
+	return ed, nil
 }
 
 // Name returns the algorithm's name.
@@ -61,6 +111,11 @@ func (*Ed25519) Name() string {
 	return "EdDSA"
 }
 
+// Public returns the Ed25519 public key.
+func (ed *Ed25519) Public() crypto.PublicKey {
+	return ed.pub
+}
+
 // Sign signs headerPayload using the Ed25519 algorithm.
 func (ed *Ed25519) Sign(headerPayload []byte) ([]byte, error) {
 	if ed.priv == nil {
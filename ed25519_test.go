@@ -96,3 +96,36 @@ func TestNewEd25519(t *testing.T) {
 		})
 	}
 }
+
+func TestNewCheckedEd25519(t *testing.T) {
+	// The key types depend on the Go version, so options are combined instead.
+	both := func(priv, pub func(*jwt.Ed25519)) func(*jwt.Ed25519) {
+		return func(ed *jwt.Ed25519) {
+			priv(ed)
+			pub(ed)
+		}
+	}
+	testCases := []struct {
+		builder func(...func(*jwt.Ed25519)) (*jwt.Ed25519, error)
+		opts    func(*jwt.Ed25519)
+		err     error
+	}{
+		{jwt.NewCheckedEd25519, nil, jwt.ErrEd25519NilPrivKey},
+		{jwt.NewCheckedEd25519, jwt.Ed25519PrivateKey(nil), jwt.ErrEd25519NilPrivKey},
+		{jwt.NewCheckedEd25519, jwt.Ed25519PrivateKey(ed25519PrivateKey1), nil},
+		{jwt.NewCheckedEd25519, jwt.Ed25519PublicKey(ed25519PublicKey1), nil},
+		{jwt.NewCheckedEd25519, jwt.Ed25519PrivateKey(ed25519PrivateKey1[:32]), jwt.ErrEd25519KeySize},
+		{jwt.NewCheckedEd25519, jwt.Ed25519PublicKey(ed25519PublicKey1[:16]), jwt.ErrEd25519KeySize},
+		{jwt.NewCheckedEd25519, both(jwt.Ed25519PrivateKey(ed25519PrivateKey1), jwt.Ed25519PublicKey(ed25519PublicKey1)), nil},
+		{jwt.NewCheckedEd25519, both(jwt.Ed25519PrivateKey(ed25519PrivateKey1), jwt.Ed25519PublicKey(ed25519PublicKey2)), jwt.ErrEd25519KeyMismatch},
+	}
+	for _, tc := range testCases {
+		funcName := funcName(tc.builder)
+		t.Run(funcName, func(t *testing.T) {
+			_, err := tc.builder(tc.opts)
+			if want, got := tc.err, err; !internal.ErrorIs(got, want) {
+				t.Errorf("jwt.%s err mismatch (-want +got):\n%s", funcName, cmp.Diff(want, got))
+			}
+		})
+	}
+}
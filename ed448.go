@@ -0,0 +1,137 @@
+package jwt
+
+import (
+	"bytes"
+	"crypto"
+
+	"github.com/cloudflare/circl/sign/ed448"
+	"github.com/gbrlsnchs/jwt/v3/internal"
+)
+
+var (
+	// ErrEd448NilPrivKey is the error for trying to sign a JWT with a nil private key.
+	ErrEd448NilPrivKey = internal.NewError("jwt: Ed448 private key is nil")
+	// ErrEd448NilPubKey is the error for trying to verify a JWT with a nil public key.
+	ErrEd448NilPubKey = internal.NewError("jwt: Ed448 public key is nil")
+	// ErrEd448KeySize is the error for an Ed448 key of invalid size.
+	ErrEd448KeySize = internal.NewError("jwt: Ed448 key has an invalid size")
+	// ErrEd448KeyMismatch is the error for an Ed448 private key that doesn't match the public key it is set with.
+	ErrEd448KeyMismatch = internal.NewError("jwt: Ed448 private key doesn't match public key")
+	// ErrEd448Verification is the error for when verification with Ed448 fails.
+	ErrEd448Verification = internal.NewError("jwt: Ed448 verification failed")
+
+	_ Algorithm = new(Ed448)
+)
+
+// Ed448PrivateKey is an option to set a private key to the Ed448 algorithm.
+func Ed448PrivateKey(priv ed448.PrivateKey) func(*Ed448) {
+	return func(ed *Ed448) {
+		ed.priv = priv
+	}
+}
+
+// Ed448PublicKey is an option to set a public key to the Ed448 algorithm.
+func Ed448PublicKey(pub ed448.PublicKey) func(*Ed448) {
+	return func(ed *Ed448) {
+		ed.pub = pub
+	}
+}
+
+// Ed448 is an algorithm that uses EdDSA over edwards448 to sign SHAKE256 hashes,
+// with keys from github.com/cloudflare/circl/sign/ed448.
+type Ed448 struct {
+	priv ed448.PrivateKey
+	pub  ed448.PublicKey
+}
+
+// NewEd448 creates a new algorithm using EdDSA and SHAKE256.
+func NewEd448(opts ...func(*Ed448)) *Ed448 {
+	ed, err := NewCheckedEd448(opts...)
+	if err != nil {
+		panic(err)
+	}
+	return ed
+}
+
+// NewCheckedEd448 is like NewEd448, but returns an error instead of panicking
+// and rejects keys of invalid size or not matching each other.
+func NewCheckedEd448(opts ...func(*Ed448)) (*Ed448, error) {
+	var ed Ed448
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&ed)
+		}
+	}
+	if ed.pub == nil {
+		if len(ed.priv) == 0 {
+			return nil, ErrEd448NilPrivKey
+		}
+		if len(ed.priv) != ed448.PrivateKeySize {
+			return nil, ErrEd448KeySize
+		}
+		ed.pub = ed.priv.Public().(ed448.PublicKey)
+	}
+	if ed.priv != nil && len(ed.priv) != ed448.PrivateKeySize || len(ed.pub) != ed448.PublicKeySize {
+		return nil, ErrEd448KeySize
+	}
+	if ed.priv != nil && !bytes.Equal(ed.priv.Public().(ed448.PublicKey), ed.pub) {
+		return nil, ErrEd448KeyMismatch
+	}
+	return &ed, nil
+}
+
+// edDSAFactory creates either an Ed448 or an Ed25519 algorithm,
+// since both share the "EdDSA" name and are told apart by their keys.
+func edDSAFactory(key interface{}) (Algorithm, error) {
+	var opt func(*Ed448)
+	switch key := key.(type) {
+	case ed448.PrivateKey:
+		opt = Ed448PrivateKey(key)
+	case ed448.PublicKey:
+		opt = Ed448PublicKey(key)
+	default:
+		return ed25519Factory(key)
+	}
+	ed, err := NewCheckedEd448(opt)
+	if err != nil {
+		return nil, err // don't return a typed nil
+	}
+	return ed, nil
+}
+
+// Name returns the algorithm's name.
+func (*Ed448) Name() string {
+	return "EdDSA"
+}
+
+// Public returns the Ed448 public key.
+func (ed *Ed448) Public() crypto.PublicKey {
+	return ed.pub
+}
+
+// Sign signs headerPayload using the Ed448 algorithm.
+func (ed *Ed448) Sign(headerPayload []byte) ([]byte, error) {
+	if ed.priv == nil {
+		return nil, ErrEd448NilPrivKey
+	}
+	return ed448.Sign(ed.priv, headerPayload, ""), nil
+}
+
+// Size returns the signature byte size.
+func (*Ed448) Size() int {
+	return ed448.SignatureSize
+}
+
+// Verify verifies a payload and a signature.
+func (ed *Ed448) Verify(payload, sig []byte) (err error) {
+	if ed.pub == nil {
+		return ErrEd448NilPubKey
+	}
+	if sig, err = internal.DecodeToBytes(sig); err != nil {
+		return err
+	}
+	if !ed448.Verify(ed.pub, payload, sig, "") {
+		return ErrEd448Verification
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+package jwt_test
+
+import (
+	"crypto/rand"
+	"testing"
+
+	"github.com/cloudflare/circl/sign/ed448"
+	"github.com/gbrlsnchs/jwt/v3"
+	"github.com/gbrlsnchs/jwt/v3/internal"
+	"github.com/google/go-cmp/cmp"
+)
+
+var (
+	ed448PublicKey1, ed448PrivateKey1 = genEd448Keys()
+	ed448PublicKey2, ed448PrivateKey2 = genEd448Keys()
+
+	ed448TestCases = []testCase{
+		{
+			alg:       jwt.NewEd448(jwt.Ed448PrivateKey(ed448PrivateKey1)),
+			payload:   tp,
+			verifyAlg: jwt.NewEd448(jwt.Ed448PrivateKey(ed448PrivateKey1)),
+			wantHeader: jwt.Header{
+				Algorithm: "EdDSA",
+				Type:      "JWT",
+			},
+			wantPayload: tp,
+			signErr:     nil,
+			verifyErr:   nil,
+		},
+		{
+			alg:       jwt.NewEd448(jwt.Ed448PrivateKey(ed448PrivateKey1)),
+			payload:   tp,
+			verifyAlg: jwt.NewEd448(jwt.Ed448PublicKey(ed448PublicKey1)),
+			wantHeader: jwt.Header{
+				Algorithm: "EdDSA",
+				Type:      "JWT",
+			},
+			wantPayload: tp,
+			signErr:     nil,
+			verifyErr:   nil,
+		},
+		{
+			alg:       jwt.NewEd448(jwt.Ed448PrivateKey(ed448PrivateKey1)),
+			payload:   tp,
+			verifyAlg: jwt.NewEd448(jwt.Ed448PrivateKey(ed448PrivateKey2)),
+			wantHeader: jwt.Header{
+				Algorithm: "EdDSA",
+				Type:      "JWT",
+			},
+			wantPayload: testPayload{},
+			signErr:     nil,
+			verifyErr:   jwt.ErrEd448Verification,
+		},
+		{
+			alg:       jwt.NewEd448(jwt.Ed448PrivateKey(ed448PrivateKey1)),
+			payload:   tp,
+			verifyAlg: jwt.NewEd448(jwt.Ed448PublicKey(ed448PublicKey2)),
+			wantHeader: jwt.Header{
+				Algorithm: "EdDSA",
+				Type:      "JWT",
+			},
+			wantPayload: testPayload{},
+			signErr:     nil,
+			verifyErr:   jwt.ErrEd448Verification,
+		},
+	}
+)
+
+func TestNewCheckedEd448(t *testing.T) {
+	keys := func(priv ed448.PrivateKey, pub ed448.PublicKey) func(*jwt.Ed448) {
+		return func(ed *jwt.Ed448) {
+			jwt.Ed448PrivateKey(priv)(ed)
+			jwt.Ed448PublicKey(pub)(ed)
+		}
+	}
+	testCases := []struct {
+		builder func(...func(*jwt.Ed448)) (*jwt.Ed448, error)
+		opts    func(*jwt.Ed448)
+		err     error
+	}{
+		{jwt.NewCheckedEd448, nil, jwt.ErrEd448NilPrivKey},
+		{jwt.NewCheckedEd448, jwt.Ed448PrivateKey(nil), jwt.ErrEd448NilPrivKey},
+		{jwt.NewCheckedEd448, jwt.Ed448PrivateKey(ed448PrivateKey1), nil},
+		{jwt.NewCheckedEd448, jwt.Ed448PublicKey(ed448PublicKey1), nil},
+		{jwt.NewCheckedEd448, jwt.Ed448PrivateKey(ed448PrivateKey1[:57]), jwt.ErrEd448KeySize},
+		{jwt.NewCheckedEd448, jwt.Ed448PublicKey(ed448PublicKey1[:32]), jwt.ErrEd448KeySize},
+		{jwt.NewCheckedEd448, keys(ed448PrivateKey1, ed448PublicKey1), nil},
+		{jwt.NewCheckedEd448, keys(ed448PrivateKey1, ed448PublicKey2), jwt.ErrEd448KeyMismatch},
+	}
+	for _, tc := range testCases {
+		funcName := funcName(tc.builder)
+		t.Run(funcName, func(t *testing.T) {
+			_, err := tc.builder(tc.opts)
+			if want, got := tc.err, err; !internal.ErrorIs(got, want) {
+				t.Errorf("jwt.%s err mismatch (-want +got):\n%s", funcName, cmp.Diff(want, got))
+			}
+		})
+	}
+}
+
+func genEd448Keys() (ed448.PublicKey, ed448.PrivateKey) {
+	pub, priv, err := ed448.GenerateKey(rand.Reader)
+	if err != nil {
+		panic(err)
+	}
+	return pub, priv
+}
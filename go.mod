@@ -1,11 +1,13 @@
 module github.com/gbrlsnchs/jwt/v3
 
-go 1.10
+go 1.16
 
 require (
+	github.com/cloudflare/circl v1.1.0
+	github.com/decred/dcrd/dcrec/secp256k1/v4 v4.0.1
 	github.com/google/go-cmp v0.4.0
 	github.com/magefile/mage v1.9.0
-	golang.org/x/crypto v0.0.0-20190927123631-a832865fa7ad
+	golang.org/x/crypto v0.0.0-20210921155107-089bfa567519
 	golang.org/x/lint v0.0.0-20190909230951-414d861bb4ac
 	golang.org/x/tools v0.0.0-20190927191325-030b2cf1153e
 	golang.org/x/xerrors v0.0.0-20191204190536-9bdfabe68543
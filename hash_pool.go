@@ -14,14 +14,17 @@ func newHashPool(hfunc func() hash.Hash) *hashPool {
 }
 
 func (hp *hashPool) sign(headerPayload []byte) ([]byte, error) {
-	hh := hp.Pool.Get().(hash.Hash)
-	defer func() {
-		hh.Reset()
-		hp.Pool.Put(hh)
-	}()
+	return hp.sum(nil, headerPayload)
+}
 
-	if _, err := hh.Write(headerPayload); err != nil {
-		return nil, err
+// sum appends the hash of headerPayload to dst, so it doesn't allocate when dst has enough capacity.
+func (hp *hashPool) sum(dst, headerPayload []byte) ([]byte, error) {
+	hh := hp.Pool.Get().(hash.Hash)
+	_, err := hh.Write(headerPayload)
+	if err == nil {
+		dst = hh.Sum(dst)
 	}
-	return hh.Sum(nil), nil
+	hh.Reset()
+	hp.Pool.Put(hh)
+	return dst, err
 }
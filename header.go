@@ -6,6 +6,16 @@ package jwt
 type Header struct {
 	Algorithm   string `json:"alg,omitempty"`
 	ContentType string `json:"cty,omitempty"`
+	JSONWebKey  *JWK   `json:"jwk,omitempty"`
 	KeyID       string `json:"kid,omitempty"`
 	Type        string `json:"typ,omitempty"`
 }
+
+// clone returns a copy of hd that doesn't share its JWK, so cached headers can't be modified through it.
+func (hd Header) clone() Header {
+	if hd.JSONWebKey != nil {
+		jwk := *hd.JSONWebKey
+		hd.JSONWebKey = &jwk
+	}
+	return hd
+}
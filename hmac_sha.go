@@ -11,6 +11,8 @@ import (
 var (
 	// ErrHMACMissingKey is the error for trying to sign or verify a JWT with an empty key.
 	ErrHMACMissingKey = internal.NewError("jwt: HMAC key is empty")
+	// ErrHMACKeyTooShort is the error for an HMAC key shorter than the hash output, as per the RFC 7518.
+	ErrHMACKeyTooShort = internal.NewError("jwt: HMAC key is too short")
 	// ErrHMACVerification is the error for an invalid signature.
 	ErrHMACVerification = internal.NewError("jwt: HMAC verification failed")
 
@@ -39,6 +41,30 @@ func newHMACSHA(name string, key []byte, sha crypto.Hash) *HMACSHA {
 	}
 }
 
+func newCheckedHMACSHA(name string, key []byte, sha crypto.Hash) (*HMACSHA, error) {
+	switch {
+	case len(key) == 0:
+		return nil, ErrHMACMissingKey
+	case len(key) < sha.Size():
+		return nil, ErrHMACKeyTooShort
+	}
+	return newHMACSHA(name, key, sha), nil
+}
+
+func hmacSHAFactory(name string, fn func([]byte) (*HMACSHA, error)) AlgorithmFactory {
+	return func(key interface{}) (Algorithm, error) {
+		secret, ok := key.([]byte)
+		if !ok {
+			return nil, internal.Errorf("jwt: %q: %w", name, ErrInvalidKey)
+		}
+		hs, err := fn(secret)
+		if err != nil {
+			return nil, err // don't return a typed nil
+		}
+		return hs, nil
+	}
+}
+
 // NewHS256 creates a new algorithm using HMAC and SHA-256.
 func NewHS256(key []byte) *HMACSHA {
 	return newHMACSHA("HS256", key, crypto.SHA256)
@@ -54,6 +80,24 @@ func NewHS512(key []byte) *HMACSHA {
 	return newHMACSHA("HS512", key, crypto.SHA512)
 }
 
+// NewCheckedHS256 is like NewHS256, but returns an error instead of panicking
+// and rejects keys shorter than 256 bits.
+func NewCheckedHS256(key []byte) (*HMACSHA, error) {
+	return newCheckedHMACSHA("HS256", key, crypto.SHA256)
+}
+
+// NewCheckedHS384 is like NewHS384, but returns an error instead of panicking
+// and rejects keys shorter than 384 bits.
+func NewCheckedHS384(key []byte) (*HMACSHA, error) {
+	return newCheckedHMACSHA("HS384", key, crypto.SHA384)
+}
+
+// NewCheckedHS512 is like NewHS512, but returns an error instead of panicking
+// and rejects keys shorter than 512 bits.
+func NewCheckedHS512(key []byte) (*HMACSHA, error) {
+	return newCheckedHMACSHA("HS512", key, crypto.SHA512)
+}
+
 // Name returns the algorithm's name.
 func (hs *HMACSHA) Name() string {
 	return hs.name
@@ -74,14 +118,23 @@ func (hs *HMACSHA) Size() int {
 
 // Verify verifies a signature based on headerPayload using HMAC-SHA.
 func (hs *HMACSHA) Verify(headerPayload, sig []byte) (err error) {
-	if sig, err = internal.DecodeToBytes(sig); err != nil {
+	sc := scratchPool.Get().(*verifyScratch)
+	defer scratchPool.Put(sc)
+	if sc.sig, err = decodeAppend(sc.sig[:0], sig); err != nil {
 		return err
 	}
-	sig2, err := hs.Sign(headerPayload)
-	if err != nil {
+	return hs.verify(headerPayload, sc.sig, sc)
+}
+
+func (hs *HMACSHA) verify(headerPayload, sig []byte, sc *verifyScratch) error {
+	if string(hs.key) == "" {
+		return ErrHMACMissingKey
+	}
+	var err error
+	if sc.sum, err = hs.pool.sum(sc.sum[:0], headerPayload); err != nil {
 		return err
 	}
-	if !hmac.Equal(sig, sig2) {
+	if !hmac.Equal(sig, sc.sum) {
 		return ErrHMACVerification
 	}
 	return nil
@@ -165,6 +165,33 @@ func TestNewHMACSHA(t *testing.T) {
 	}
 }
 
+func TestNewCheckedHMACSHA(t *testing.T) {
+	testCases := []struct {
+		builder func([]byte) (*jwt.HMACSHA, error)
+		key     []byte
+		err     error
+	}{
+		{jwt.NewCheckedHS256, nil, jwt.ErrHMACMissingKey},
+		{jwt.NewCheckedHS256, []byte("a"), jwt.ErrHMACKeyTooShort},
+		{jwt.NewCheckedHS256, make([]byte, 32), nil},
+		{jwt.NewCheckedHS384, nil, jwt.ErrHMACMissingKey},
+		{jwt.NewCheckedHS384, make([]byte, 32), jwt.ErrHMACKeyTooShort},
+		{jwt.NewCheckedHS384, make([]byte, 48), nil},
+		{jwt.NewCheckedHS512, nil, jwt.ErrHMACMissingKey},
+		{jwt.NewCheckedHS512, make([]byte, 48), jwt.ErrHMACKeyTooShort},
+		{jwt.NewCheckedHS512, make([]byte, 64), nil},
+	}
+	for _, tc := range testCases {
+		funcName := funcName(tc.builder)
+		t.Run(funcName, func(t *testing.T) {
+			_, err := tc.builder(tc.key)
+			if want, got := tc.err, err; !internal.ErrorIs(got, want) {
+				t.Errorf("jwt.%s err mismatch (-want +got):\n%s", funcName, cmp.Diff(want, got))
+			}
+		})
+	}
+}
+
 func funcName(fn interface{}) string {
 	return strings.Split(
 		runtime.FuncForPC(reflect.ValueOf(fn).Pointer()).Name(),
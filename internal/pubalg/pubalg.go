@@ -0,0 +1,36 @@
+// Package pubalg creates verification-only algorithms from public keys
+// embedded in tokens, like in the "jwk" header or the "cnf" claim.
+package pubalg
+
+import (
+	"crypto"
+
+	"github.com/gbrlsnchs/jwt/v3"
+	"github.com/gbrlsnchs/jwt/v3/internal"
+)
+
+// ErrUnsupported is the error for when an algorithm can't be used with a public key.
+var ErrUnsupported = internal.NewError("unsupported algorithm for public key")
+
+// New creates an algorithm named name that verifies signatures with pub.
+// Symmetric algorithms are never returned since the key is public.
+// Keys are validated by jwt.NewAlgorithm, so weak keys are rejected.
+func New(name string, pub crypto.PublicKey) (jwt.Algorithm, error) {
+	if _, ok := pub.([]byte); ok {
+		return nil, ErrUnsupported
+	}
+	alg, err := jwt.NewAlgorithm(name, pub)
+	if internal.ErrorIs(err, jwt.ErrUnsupportedAlg) || internal.ErrorIs(err, jwt.ErrInvalidKey) {
+		return nil, ErrUnsupported
+	}
+	return alg, err
+}
+
+// FromJWK creates an algorithm named name that verifies signatures with the key represented by jwk.
+func FromJWK(name string, jwk *jwt.JWK) (jwt.Algorithm, error) {
+	pub, err := jwk.PublicKey()
+	if err != nil {
+		return nil, err
+	}
+	return New(name, pub)
+}
@@ -0,0 +1,15 @@
+package internal
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+)
+
+// RandomID generates a Base64 encoded random string from n random bytes.
+func RandomID(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
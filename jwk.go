@@ -0,0 +1,213 @@
+package jwt
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+
+	"github.com/cloudflare/circl/sign/ed448"
+	"github.com/gbrlsnchs/jwt/v3/internal"
+)
+
+var (
+	// ErrJWKUnsupported is the error for when a key type or curve can't be represented as a JWK.
+	ErrJWKUnsupported = internal.NewError("jwt: unsupported JWK key type")
+	// ErrJWKInvalid is the error for a JWK with missing or malformed members.
+	ErrJWKInvalid = internal.NewError("jwt: invalid JWK")
+)
+
+// JWK is a JSON Web Key narrowed down to public keys, as per the RFC 7517.
+type JWK struct {
+	KeyType   string `json:"kty"`
+	Use       string `json:"use,omitempty"`
+	Algorithm string `json:"alg,omitempty"`
+	KeyID     string `json:"kid,omitempty"`
+
+	// Elliptic curve and octet key pair parameters.
+	Curve string `json:"crv,omitempty"`
+	X     string `json:"x,omitempty"`
+	Y     string `json:"y,omitempty"`
+
+	// RSA parameters.
+	N string `json:"n,omitempty"`
+	E string `json:"e,omitempty"`
+}
+
+// NewJWK creates a JWK from a public key. Private keys are accepted
+// as long as they implement crypto.Signer, in which case only the public part is used.
+func NewJWK(key crypto.PublicKey) (*JWK, error) {
+	if signer, ok := key.(crypto.Signer); ok {
+		key = signer.Public()
+	}
+	enc := base64.RawURLEncoding
+	switch pub := key.(type) {
+	case *rsa.PublicKey:
+		return &JWK{
+			KeyType: "RSA",
+			N:       enc.EncodeToString(pub.N.Bytes()),
+			E:       enc.EncodeToString(big.NewInt(int64(pub.E)).Bytes()),
+		}, nil
+	case *ecdsa.PublicKey:
+		crv, ok := curveName(pub.Curve)
+		if !ok {
+			return nil, ErrJWKUnsupported
+		}
+		size := byteSize(pub.Params().BitSize)
+		return &JWK{
+			KeyType: "EC",
+			Curve:   crv,
+			X:       enc.EncodeToString(padBytes(pub.X.Bytes(), size)),
+			Y:       enc.EncodeToString(padBytes(pub.Y.Bytes(), size)),
+		}, nil
+	}
+	if pub, ok := key.(ed448.PublicKey); ok {
+		return &JWK{
+			KeyType: "OKP",
+			Curve:   "Ed448",
+			X:       enc.EncodeToString(pub),
+		}, nil
+	}
+	if jwk, ok := newOKPJWK(key); ok {
+		return jwk, nil
+	}
+	return nil, ErrJWKUnsupported
+}
+
+// PublicKey returns the public key represented by the JWK.
+func (jwk *JWK) PublicKey() (crypto.PublicKey, error) {
+	switch jwk.KeyType {
+	case "RSA":
+		n, err := decodeJWKInt(jwk.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeJWKInt(jwk.E)
+		if err != nil {
+			return nil, err
+		}
+		if !e.IsInt64() || e.Int64() > 1<<31-1 {
+			return nil, ErrJWKInvalid
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		c, ok := curveByName(jwk.Curve)
+		if !ok {
+			return nil, ErrJWKUnsupported
+		}
+		x, err := decodeJWKInt(jwk.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeJWKInt(jwk.Y)
+		if err != nil {
+			return nil, err
+		}
+		if !c.IsOnCurve(x, y) {
+			return nil, ErrJWKInvalid
+		}
+		return &ecdsa.PublicKey{Curve: c, X: x, Y: y}, nil
+	case "OKP":
+		x, err := internal.DecodeToBytes([]byte(jwk.X))
+		if err != nil {
+			return nil, ErrJWKInvalid
+		}
+		if jwk.Curve == "Ed448" {
+			if len(x) != ed448.PublicKeySize {
+				return nil, ErrJWKInvalid
+			}
+			return ed448.PublicKey(x), nil
+		}
+		return okpPublicKey(jwk.Curve, x)
+	}
+	return nil, ErrJWKUnsupported
+}
+
+// Thumbprint computes the JWK thumbprint using h, as per the RFC 7638.
+func (jwk *JWK) Thumbprint(h crypto.Hash) ([]byte, error) {
+	var (
+		b   []byte
+		err error
+	)
+	// Required members must be in lexicographic order, hence the anonymous structs.
+	switch jwk.KeyType {
+	case "RSA":
+		b, err = json.Marshal(struct {
+			E   string `json:"e"`
+			Kty string `json:"kty"`
+			N   string `json:"n"`
+		}{jwk.E, jwk.KeyType, jwk.N})
+	case "EC":
+		b, err = json.Marshal(struct {
+			Crv string `json:"crv"`
+			Kty string `json:"kty"`
+			X   string `json:"x"`
+			Y   string `json:"y"`
+		}{jwk.Curve, jwk.KeyType, jwk.X, jwk.Y})
+	case "OKP":
+		b, err = json.Marshal(struct {
+			Crv string `json:"crv"`
+			Kty string `json:"kty"`
+			X   string `json:"x"`
+		}{jwk.Curve, jwk.KeyType, jwk.X})
+	default:
+		return nil, ErrJWKUnsupported
+	}
+	if err != nil {
+		return nil, err
+	}
+	hh := h.New()
+	hh.Write(b)
+	return hh.Sum(nil), nil
+}
+
+func curveName(c elliptic.Curve) (string, bool) {
+	switch c {
+	case elliptic.P256():
+		return "P-256", true
+	case elliptic.P384():
+		return "P-384", true
+	case elliptic.P521():
+		return "P-521", true
+	case Secp256k1():
+		return "secp256k1", true
+	}
+	return "", false
+}
+
+func curveByName(crv string) (elliptic.Curve, bool) {
+	switch crv {
+	case "P-256":
+		return elliptic.P256(), true
+	case "P-384":
+		return elliptic.P384(), true
+	case "P-521":
+		return elliptic.P521(), true
+	case "secp256k1":
+		return Secp256k1(), true
+	}
+	return nil, false
+}
+
+func decodeJWKInt(s string) (*big.Int, error) {
+	if s == "" {
+		return nil, ErrJWKInvalid
+	}
+	b, err := internal.DecodeToBytes([]byte(s))
+	if err != nil {
+		return nil, ErrJWKInvalid
+	}
+	return new(big.Int).SetBytes(b), nil
+}
+
+func padBytes(b []byte, size int) []byte {
+	if len(b) >= size {
+		return b
+	}
+	padded := make([]byte, size)
+	copy(padded[size-len(b):], b)
+	return padded
+}
@@ -0,0 +1,32 @@
+// +build !go1.13
+
+package jwt
+
+import (
+	"crypto"
+	"encoding/base64"
+
+	"golang.org/x/crypto/ed25519"
+)
+
+func newOKPJWK(key crypto.PublicKey) (*JWK, bool) {
+	pub, ok := key.(ed25519.PublicKey)
+	if !ok {
+		return nil, false
+	}
+	return &JWK{
+		KeyType: "OKP",
+		Curve:   "Ed25519",
+		X:       base64.RawURLEncoding.EncodeToString(pub),
+	}, true
+}
+
+func okpPublicKey(crv string, x []byte) (crypto.PublicKey, error) {
+	if crv != "Ed25519" {
+		return nil, ErrJWKUnsupported
+	}
+	if len(x) != ed25519.PublicKeySize {
+		return nil, ErrJWKInvalid
+	}
+	return ed25519.PublicKey(x), nil
+}
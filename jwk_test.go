@@ -0,0 +1,88 @@
+package jwt_test
+
+import (
+	"crypto"
+	"encoding/base64"
+	"testing"
+
+	"github.com/gbrlsnchs/jwt/v3"
+	"github.com/gbrlsnchs/jwt/v3/internal"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestJWK(t *testing.T) {
+	testCases := []struct {
+		name string
+		key  crypto.PublicKey
+		kty  string
+		crv  string
+	}{
+		{"RSA", rsaPublicKey1, "RSA", ""},
+		{"RSA private key", rsaPrivateKey1, "RSA", ""},
+		{"P-256", es256PublicKey1, "EC", "P-256"},
+		{"P-384", es384PublicKey1, "EC", "P-384"},
+		{"P-521", es512PublicKey1, "EC", "P-521"},
+		{"secp256k1", es256kPublicKey1, "EC", "secp256k1"},
+		{"Ed25519", ed25519PublicKey1, "OKP", "Ed25519"},
+		{"Ed448", ed448PublicKey1, "OKP", "Ed448"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			jwk, err := jwt.NewJWK(tc.key)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want, got := tc.kty, jwk.KeyType; got != want {
+				t.Errorf("jwt.JWK.KeyType mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+			if want, got := tc.crv, jwk.Curve; got != want {
+				t.Errorf("jwt.JWK.Curve mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+			pub, err := jwk.PublicKey()
+			if err != nil {
+				t.Fatal(err)
+			}
+			jwk2, err := jwt.NewJWK(pub)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want, got := jwk, jwk2; !cmp.Equal(got, want) {
+				t.Errorf("jwt.JWK.PublicKey mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+		})
+	}
+
+	t.Run("unsupported", func(t *testing.T) {
+		if _, err := jwt.NewJWK([]byte("secret")); !internal.ErrorIs(err, jwt.ErrJWKUnsupported) {
+			t.Errorf("jwt.NewJWK err mismatch (-want +got):\n%s", cmp.Diff(jwt.ErrJWKUnsupported, err))
+		}
+	})
+	t.Run("invalid point", func(t *testing.T) {
+		jwk, err := jwt.NewJWK(es256PublicKey1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		jwk.X, jwk.Y = jwk.Y, jwk.X
+		if _, err = jwk.PublicKey(); !internal.ErrorIs(err, jwt.ErrJWKInvalid) {
+			t.Errorf("jwt.JWK.PublicKey err mismatch (-want +got):\n%s", cmp.Diff(jwt.ErrJWKInvalid, err))
+		}
+	})
+}
+
+func TestJWKThumbprint(t *testing.T) {
+	// Example from the RFC 7638, section 3.1.
+	jwk := jwt.JWK{
+		KeyType:   "RSA",
+		N:         "0vx7agoebGcQSuuPiLJXZptN9nndrQmbXEps2aiAFbWhM78LhWx4cbbfAAtVT86zwu1RK7aPFFxuhDR1L6tSoc_BJECPebWKRXjBZCiFV4n3oknjhMstn64tZ_2W-5JsGY4Hc5n9yBXArwl93lqt7_RN5w6Cf0h4QyQ5v-65YGjQR0_FDW2QvzqY368QQMicAtaSqzs8KJZgnYb9c7d0zgdAZHzu6qMQvRL5hajrn1n91CbOpbISD08qNLyrdkt-bFTWhAI4vMQFh6WeZu0fM4lFd2NcRwr3XPksINHaQ-G_xBniIqbw0Ls1jF44-csFCur-kEgU8awapJzKnqDKgw",
+		E:         "AQAB",
+		Algorithm: "RS256",
+		KeyID:     "2011-04-29",
+	}
+	b, err := jwk.Thumbprint(crypto.SHA256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, got := "NzbLsXh8uDCcd-6MNwXF4W_7noWXFZAfHkxZsRGC9Xs", base64.RawURLEncoding.EncodeToString(b); got != want {
+		t.Errorf("jwt.JWK.Thumbprint mismatch (-want +got):\n%s", cmp.Diff(want, got))
+	}
+}
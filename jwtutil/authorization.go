@@ -0,0 +1,72 @@
+package jwtutil
+
+import (
+	"net/http"
+	"strings"
+)
+
+// RequireScope wraps next so that it only serves requests whose verified payload has been granted all scopes.
+// The payload is read from the request's context, as stored by Bearer, and must have
+// a "HasScope(string) bool" method, like *oauth.AccessToken.
+func RequireScope(next http.Handler, scopes ...string) http.Handler {
+	return RequireClaim(next, func(payload interface{}) bool {
+		pl, ok := payload.(interface{ HasScope(string) bool })
+		if !ok {
+			return false
+		}
+		for _, scope := range scopes {
+			if !pl.HasScope(scope) {
+				return false
+			}
+		}
+		return true
+	}, &BearerError{
+		Code:        InsufficientScope,
+		Description: "the token lacks a required scope",
+		Scope:       strings.Join(scopes, " "),
+	})
+}
+
+// RequireAnyRole wraps next so that it only serves requests whose verified payload has at least one of roles.
+// The payload is read from the request's context, as stored by Bearer, and must have
+// a "HasRole(string) bool" method, like *oauth.AccessToken.
+func RequireAnyRole(next http.Handler, roles ...string) http.Handler {
+	return RequireClaim(next, func(payload interface{}) bool {
+		pl, ok := payload.(interface{ HasRole(string) bool })
+		if !ok {
+			return false
+		}
+		for _, role := range roles {
+			if pl.HasRole(role) {
+				return true
+			}
+		}
+		return false
+	}, &BearerError{
+		Code:        InsufficientScope,
+		Description: "the token lacks a required role",
+	})
+}
+
+// RequireClaim wraps next so that it only serves requests whose verified payload satisfies allow.
+// The payload is read from the request's context, as stored by Bearer.
+// Requests not satisfying allow are answered with err, or with a generic
+// "insufficient_scope" error when err is nil. Requests without a verified payload
+// are answered with 401 Unauthorized.
+func RequireClaim(next http.Handler, allow func(payload interface{}) bool, err *BearerError) http.Handler {
+	if err == nil {
+		err = &BearerError{Code: InsufficientScope}
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		payload := PayloadFromContext(r.Context())
+		if payload == nil {
+			WriteBearerError(w, "", nil)
+			return
+		}
+		if !allow(payload) {
+			WriteBearerError(w, "", err)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,104 @@
+package jwtutil_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gbrlsnchs/jwt/v3"
+	"github.com/gbrlsnchs/jwt/v3/jwtutil"
+	"github.com/gbrlsnchs/jwt/v3/oauth"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestAuthorization(t *testing.T) {
+	var (
+		ok = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+		at = &oauth.AccessToken{
+			Payload: jwt.Payload{Subject: "someone"},
+			Scope:   oauth.NewScope("read", "write"),
+			Roles:   []string{"editor"},
+		}
+	)
+	testCases := []struct {
+		name      string
+		handler   http.Handler
+		payload   interface{}
+		status    int
+		challenge string
+	}{
+		{
+			name:    "scope",
+			handler: jwtutil.RequireScope(ok, "read", "write"),
+			payload: at,
+			status:  http.StatusOK,
+		},
+		{
+			name:      "missing scope",
+			handler:   jwtutil.RequireScope(ok, "read", "admin"),
+			payload:   at,
+			status:    http.StatusForbidden,
+			challenge: `Bearer error="insufficient_scope", error_description="the token lacks a required scope", scope="read admin"`,
+		},
+		{
+			name:      "payload without scope",
+			handler:   jwtutil.RequireScope(ok, "read"),
+			payload:   &jwt.Payload{},
+			status:    http.StatusForbidden,
+			challenge: `Bearer error="insufficient_scope", error_description="the token lacks a required scope", scope="read"`,
+		},
+		{
+			name:    "role",
+			handler: jwtutil.RequireAnyRole(ok, "admin", "editor"),
+			payload: at,
+			status:  http.StatusOK,
+		},
+		{
+			name:      "missing role",
+			handler:   jwtutil.RequireAnyRole(ok, "admin"),
+			payload:   at,
+			status:    http.StatusForbidden,
+			challenge: `Bearer error="insufficient_scope", error_description="the token lacks a required role"`,
+		},
+		{
+			name: "claim",
+			handler: jwtutil.RequireClaim(ok, func(payload interface{}) bool {
+				return payload.(*oauth.AccessToken).Subject == "someone"
+			}, nil),
+			payload: at,
+			status:  http.StatusOK,
+		},
+		{
+			name: "unsatisfied claim",
+			handler: jwtutil.RequireClaim(ok, func(payload interface{}) bool {
+				return payload.(*oauth.AccessToken).Subject == "admin"
+			}, nil),
+			payload:   at,
+			status:    http.StatusForbidden,
+			challenge: `Bearer error="insufficient_scope"`,
+		},
+		{
+			name:      "unauthenticated",
+			handler:   jwtutil.RequireScope(ok, "read"),
+			payload:   nil,
+			status:    http.StatusUnauthorized,
+			challenge: "Bearer",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tc.payload != nil {
+				r = r.WithContext(jwtutil.NewContext(r.Context(), jwt.Header{}, tc.payload))
+			}
+			w := httptest.NewRecorder()
+			tc.handler.ServeHTTP(w, r)
+			if want, got := tc.status, w.Code; got != want {
+				t.Errorf("status mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+			if want, got := tc.challenge, w.Header().Get("WWW-Authenticate"); got != want {
+				t.Errorf("WWW-Authenticate mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+		})
+	}
+}
@@ -0,0 +1,254 @@
+package jwtutil
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gbrlsnchs/jwt/v3"
+	"github.com/gbrlsnchs/jwt/v3/internal"
+)
+
+// Error codes for bearer token authentication, as per the RFC 6750.
+const (
+	InvalidRequest    = "invalid_request"
+	InvalidToken      = "invalid_token"
+	InsufficientScope = "insufficient_scope"
+)
+
+var (
+	// ErrMissingToken is the error for a request carrying no token.
+	ErrMissingToken = internal.NewError("jwtutil: missing token")
+	// ErrNoAlgorithm is the error for a Bearer with neither Algorithm nor Resolve set
+	// and for a Session with no Algorithm.
+	ErrNoAlgorithm = internal.NewError("jwtutil: no algorithm")
+)
+
+type contextKey int
+
+const (
+	headerKey contextKey = iota
+	payloadKey
+)
+
+// TokenSource extracts a token from an HTTP request.
+// It returns an empty string when the request carries no token.
+type TokenSource func(*http.Request) string
+
+// AuthorizationHeader extracts a token from the "Authorization" HTTP header using the "Bearer" scheme.
+func AuthorizationHeader(r *http.Request) string {
+	const scheme = "bearer "
+	auth := r.Header.Get("Authorization")
+	if len(auth) <= len(scheme) || !strings.EqualFold(auth[:len(scheme)], scheme) {
+		return ""
+	}
+	return strings.TrimSpace(auth[len(scheme):])
+}
+
+// Cookie extracts a token from the cookie called name.
+func Cookie(name string) TokenSource {
+	return func(r *http.Request) string {
+		c, err := r.Cookie(name)
+		if err != nil {
+			return ""
+		}
+		return c.Value
+	}
+}
+
+// QueryParameter extracts a token from the URL query parameter called name.
+// The RFC 6750 uses "access_token", but advises against sending tokens in URLs.
+func QueryParameter(name string) TokenSource {
+	return func(r *http.Request) string {
+		return r.URL.Query().Get(name)
+	}
+}
+
+// BearerError is an error response for a request to a protected resource, as per the RFC 6750.
+type BearerError struct {
+	Code        string
+	Description string
+	// Scope lists the scopes required to access the resource, separated by spaces.
+	Scope string
+	// Err is the cause of the error. It is not sent in the response.
+	Err error
+}
+
+// Error returns the error code and description.
+func (e *BearerError) Error() string {
+	if e.Description == "" {
+		return "jwtutil: " + e.Code
+	}
+	return "jwtutil: " + e.Code + ": " + e.Description
+}
+
+// Unwrap returns the cause of the error.
+func (e *BearerError) Unwrap() error {
+	return e.Err
+}
+
+// StatusCode returns the HTTP status code for the error.
+func (e *BearerError) StatusCode() int {
+	switch e.Code {
+	case InvalidRequest:
+		return http.StatusBadRequest
+	case InsufficientScope:
+		return http.StatusForbidden
+	}
+	return http.StatusUnauthorized
+}
+
+var quoteReplacer = strings.NewReplacer(`"`, "'", `\`, "")
+
+// WriteBearerError responds with a "WWW-Authenticate" challenge for err, which may be nil
+// when the request carries no token.
+func WriteBearerError(w http.ResponseWriter, realm string, err *BearerError) {
+	var params []string
+	add := func(name, value string) {
+		if value != "" {
+			params = append(params, name+`="`+quoteReplacer.Replace(value)+`"`)
+		}
+	}
+	add("realm", realm)
+	status := http.StatusUnauthorized
+	if err != nil {
+		add("error", err.Code)
+		add("error_description", err.Description)
+		add("scope", err.Scope)
+		status = err.StatusCode()
+	}
+	challenge := "Bearer"
+	if len(params) > 0 {
+		challenge += " " + strings.Join(params, ", ")
+	}
+	w.Header().Set("WWW-Authenticate", challenge)
+	http.Error(w, http.StatusText(status), status)
+}
+
+// NewContext returns a copy of ctx holding the header and payload of a verified token.
+func NewContext(ctx context.Context, hd jwt.Header, payload interface{}) context.Context {
+	ctx = context.WithValue(ctx, headerKey, hd)
+	return context.WithValue(ctx, payloadKey, payload)
+}
+
+// HeaderFromContext returns the header of the verified token held by ctx.
+func HeaderFromContext(ctx context.Context) (jwt.Header, bool) {
+	hd, ok := ctx.Value(headerKey).(jwt.Header)
+	return hd, ok
+}
+
+// PayloadFromContext returns the payload of the verified token held by ctx,
+// which is the pointer returned by Bearer.Payload, or nil if there's none.
+func PayloadFromContext(ctx context.Context) interface{} {
+	return ctx.Value(payloadKey)
+}
+
+// Bearer authenticates HTTP requests using bearer tokens.
+type Bearer struct {
+	// Algorithm verifies tokens.
+	Algorithm jwt.Algorithm
+	// Resolve is used instead of Algorithm when set, with a new Resolver for each request.
+	Resolve func(jwt.Header) (jwt.Algorithm, error)
+	// Sources extract tokens from requests. Requests carrying tokens in more than
+	// one source are rejected. Defaults to AuthorizationHeader.
+	Sources []TokenSource
+	// Payload returns a pointer to decode the payload of the token sent with r into
+	// and the options to verify it. When nil, tokens are decoded into a *jwt.Payload
+	// and its "exp" and "nbf" claims are validated.
+	Payload func(r *http.Request) (interface{}, []jwt.VerifyOption)
+	// Realm is the "realm" attribute of "WWW-Authenticate" challenges.
+	Realm string
+}
+
+// Verify extracts the token sent with r and verifies it. It returns the token's header and payload.
+// When r carries no token, ErrMissingToken is returned, and when b has neither Algorithm
+// nor Resolve set, ErrNoAlgorithm is. Otherwise, errors are of type *BearerError.
+func (b *Bearer) Verify(r *http.Request) (jwt.Header, interface{}, error) {
+	var hd jwt.Header
+	if b.Algorithm == nil && b.Resolve == nil {
+		return hd, nil, ErrNoAlgorithm
+	}
+	token, err := b.token(r)
+	if err != nil {
+		return hd, nil, err
+	}
+	if token == "" {
+		return hd, nil, ErrMissingToken
+	}
+	alg := b.Algorithm
+	if b.Resolve != nil {
+		alg = &Resolver{New: b.Resolve}
+	}
+	var (
+		payload interface{}
+		opts    []jwt.VerifyOption
+	)
+	if b.Payload != nil {
+		payload, opts = b.Payload(r)
+	} else {
+		var (
+			pl  jwt.Payload
+			now = time.Now()
+		)
+		payload = &pl
+		opts = []jwt.VerifyOption{jwt.ValidatePayload(&pl,
+			jwt.ExpirationTimeValidator(now),
+			jwt.NotBeforeValidator(now),
+		)}
+	}
+	opts = append([]jwt.VerifyOption{jwt.ValidateHeader}, opts...)
+	if hd, err = jwt.Verify([]byte(token), alg, payload, opts...); err != nil {
+		return hd, nil, &BearerError{Code: InvalidToken, Description: description(err), Err: err}
+	}
+	return hd, payload, nil
+}
+
+// Handler wraps next so that it only serves requests carrying a valid token.
+// The token's header and payload are stored in the request's context.
+func (b *Bearer) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hd, payload, err := b.Verify(r)
+		if internal.ErrorIs(err, ErrNoAlgorithm) {
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+		if err != nil {
+			// Requests carrying no token are only challenged, without an error code.
+			var berr *BearerError
+			internal.ErrorAs(err, &berr)
+			WriteBearerError(w, b.Realm, berr)
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(NewContext(r.Context(), hd, payload)))
+	})
+}
+
+func (b *Bearer) token(r *http.Request) (string, error) {
+	sources := b.Sources
+	if len(sources) == 0 {
+		sources = []TokenSource{AuthorizationHeader}
+	}
+	var token string
+	for _, src := range sources {
+		tok := src(r)
+		if tok == "" {
+			continue
+		}
+		if token != "" {
+			return "", &BearerError{Code: InvalidRequest, Description: "more than one token was sent"}
+		}
+		token = tok
+	}
+	return token, nil
+}
+
+func description(err error) string {
+	switch {
+	case internal.ErrorIs(err, jwt.ErrExpValidation):
+		return "the token has expired"
+	case internal.ErrorIs(err, jwt.ErrNbfValidation):
+		return "the token is not valid yet"
+	}
+	return "the token is invalid"
+}
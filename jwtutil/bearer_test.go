@@ -0,0 +1,142 @@
+package jwtutil_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gbrlsnchs/jwt/v3"
+	"github.com/gbrlsnchs/jwt/v3/jwtutil"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestBearer(t *testing.T) {
+	sign := func(pl jwt.Payload, alg jwt.Algorithm) string {
+		token, err := jwt.Sign(pl, alg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(token)
+	}
+	var (
+		now     = time.Now()
+		valid   = sign(jwt.Payload{Subject: "someone", ExpirationTime: jwt.NumericDate(now.Add(time.Hour))}, hs256)
+		expired = sign(jwt.Payload{Subject: "someone", ExpirationTime: jwt.NumericDate(now.Add(-time.Hour))}, hs256)
+		forged  = sign(jwt.Payload{Subject: "someone", ExpirationTime: jwt.NumericDate(now.Add(time.Hour))}, jwt.NewHS256([]byte("forged")))
+	)
+	testCases := []struct {
+		name      string
+		bearer    *jwtutil.Bearer
+		request   func(*http.Request)
+		status    int
+		challenge string
+	}{
+		{
+			name:   "authorization header",
+			bearer: &jwtutil.Bearer{Algorithm: hs256},
+			request: func(r *http.Request) {
+				r.Header.Set("Authorization", "Bearer "+valid)
+			},
+			status: http.StatusOK,
+		},
+		{
+			name: "resolver",
+			bearer: &jwtutil.Bearer{Resolve: func(jwt.Header) (jwt.Algorithm, error) {
+				return hs256, nil
+			}},
+			request: func(r *http.Request) {
+				r.Header.Set("Authorization", "bearer "+valid)
+			},
+			status: http.StatusOK,
+		},
+		{
+			name:   "cookie",
+			bearer: &jwtutil.Bearer{Algorithm: hs256, Sources: []jwtutil.TokenSource{jwtutil.Cookie("token")}},
+			request: func(r *http.Request) {
+				r.AddCookie(&http.Cookie{Name: "token", Value: valid})
+			},
+			status: http.StatusOK,
+		},
+		{
+			name:   "query parameter",
+			bearer: &jwtutil.Bearer{Algorithm: hs256, Sources: []jwtutil.TokenSource{jwtutil.QueryParameter("access_token")}},
+			request: func(r *http.Request) {
+				r.URL.RawQuery = "access_token=" + valid
+			},
+			status: http.StatusOK,
+		},
+		{
+			name:      "missing token",
+			bearer:    &jwtutil.Bearer{Algorithm: hs256, Realm: "example"},
+			request:   func(r *http.Request) {},
+			status:    http.StatusUnauthorized,
+			challenge: `Bearer realm="example"`,
+		},
+		{
+			name:   "expired token",
+			bearer: &jwtutil.Bearer{Algorithm: hs256, Realm: "example"},
+			request: func(r *http.Request) {
+				r.Header.Set("Authorization", "Bearer "+expired)
+			},
+			status:    http.StatusUnauthorized,
+			challenge: `Bearer realm="example", error="invalid_token", error_description="the token has expired"`,
+		},
+		{
+			name:   "forged token",
+			bearer: &jwtutil.Bearer{Algorithm: hs256},
+			request: func(r *http.Request) {
+				r.Header.Set("Authorization", "Bearer "+forged)
+			},
+			status:    http.StatusUnauthorized,
+			challenge: `Bearer error="invalid_token", error_description="the token is invalid"`,
+		},
+		{
+			name: "more than one token",
+			bearer: &jwtutil.Bearer{Algorithm: hs256, Sources: []jwtutil.TokenSource{
+				jwtutil.AuthorizationHeader,
+				jwtutil.QueryParameter("access_token"),
+			}},
+			request: func(r *http.Request) {
+				r.Header.Set("Authorization", "Bearer "+valid)
+				r.URL.RawQuery = "access_token=" + valid
+			},
+			status:    http.StatusBadRequest,
+			challenge: `Bearer error="invalid_request", error_description="more than one token was sent"`,
+		},
+		{
+			name:   "no algorithm",
+			bearer: &jwtutil.Bearer{},
+			request: func(r *http.Request) {
+				r.Header.Set("Authorization", "Bearer "+valid)
+			},
+			status: http.StatusInternalServerError,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			h := tc.bearer.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if _, ok := jwtutil.HeaderFromContext(r.Context()); !ok {
+					t.Errorf("missing header in context")
+				}
+				pl, ok := jwtutil.PayloadFromContext(r.Context()).(*jwt.Payload)
+				if !ok {
+					t.Fatalf("missing payload in context")
+				}
+				if want, got := "someone", pl.Subject; got != want {
+					t.Errorf("sub mismatch (-want +got):\n%s", cmp.Diff(want, got))
+				}
+			}))
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			tc.request(r)
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+			if want, got := tc.status, w.Code; got != want {
+				t.Errorf("status mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+			if want, got := tc.challenge, w.Header().Get("WWW-Authenticate"); got != want {
+				t.Errorf("WWW-Authenticate mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package jwtutil
+
+import (
+	"sync"
+	"time"
+
+	"github.com/gbrlsnchs/jwt/v3"
+	"github.com/gbrlsnchs/jwt/v3/internal"
+)
+
+// ErrReplay is the error for when a token identifier has already been used.
+var ErrReplay = internal.NewError("jwtutil: token has already been used")
+
+// ReplayCache records token identifiers in order to detect replays.
+type ReplayCache interface {
+	// Seen records id until exp and reports whether it had already been recorded.
+	Seen(id string, exp time.Time) (bool, error)
+}
+
+// purgeInterval is how often a MemoryCache purges its expired entries.
+const purgeInterval = time.Minute
+
+// MemoryCache is an in-memory ReplayCache.
+// Expired entries are ignored right away and purged at most once per minute.
+type MemoryCache struct {
+	// Now is the clock used to expire entries. Defaults to time.Now.
+	Now func() time.Time
+
+	mu        sync.Mutex
+	entries   map[string]time.Time
+	nextPurge time.Time
+}
+
+// NewMemoryCache creates an empty in-memory cache.
+func NewMemoryCache() *MemoryCache {
+	return &MemoryCache{entries: make(map[string]time.Time)}
+}
+
+// Seen records id until exp and reports whether it had already been recorded.
+func (mc *MemoryCache) Seen(id string, exp time.Time) (bool, error) {
+	now := time.Now
+	if mc.Now != nil {
+		now = mc.Now
+	}
+	t := now()
+
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+	if mc.entries == nil {
+		mc.entries = make(map[string]time.Time)
+	}
+	if t.After(mc.nextPurge) {
+		for k, v := range mc.entries {
+			if t.After(v) {
+				delete(mc.entries, k)
+			}
+		}
+		mc.nextPurge = t.Add(purgeInterval)
+	}
+	if v, ok := mc.entries[id]; ok && !t.After(v) {
+		return true, nil
+	}
+	mc.entries[id] = exp
+	return false, nil
+}
+
+// ReplayValidator validates the "jti" claim has not been seen before by rc.
+// The identifier is remembered until the token expires or, when "exp" is absent, for ttl after now.
+func ReplayValidator(rc ReplayCache, now time.Time, ttl time.Duration) jwt.Validator {
+	return func(pl *jwt.Payload) error {
+		if pl.JWTID == "" {
+			return jwt.ErrJtiValidation
+		}
+		var exp time.Time
+		if pl.ExpirationTime != nil {
+			exp = pl.ExpirationTime.Time
+		} else {
+			exp = now.Add(ttl)
+		}
+		seen, err := rc.Seen(pl.JWTID, exp)
+		if err != nil {
+			return err
+		}
+		if seen {
+			return internal.Errorf("jwtutil: %q: %w", pl.JWTID, ErrReplay)
+		}
+		return nil
+	}
+}
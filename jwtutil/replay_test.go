@@ -0,0 +1,80 @@
+package jwtutil_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gbrlsnchs/jwt/v3"
+	"github.com/gbrlsnchs/jwt/v3/internal"
+	"github.com/gbrlsnchs/jwt/v3/jwtutil"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestMemoryCache(t *testing.T) {
+	now := time.Now()
+	mc := jwtutil.NewMemoryCache()
+	mc.Now = func() time.Time { return now }
+	testCases := []struct {
+		id   string
+		exp  time.Time
+		now  time.Time
+		seen bool
+	}{
+		{"foo", now.Add(time.Minute), now, false},
+		{"foo", now.Add(time.Minute), now, true},
+		{"bar", now.Add(time.Minute), now, false},
+		{"foo", now.Add(time.Hour), now.Add(2 * time.Minute), false},
+		{"bar", now.Add(time.Hour), now.Add(2 * time.Minute), false},
+		{"foo", now.Add(time.Hour), now.Add(2 * time.Minute), true},
+		// Expired entries are ignored even before being purged.
+		{"baz", now.Add(2*time.Minute + 10*time.Second), now.Add(2 * time.Minute), false},
+		{"baz", now.Add(time.Hour), now.Add(2*time.Minute + 20*time.Second), false},
+		{"baz", now.Add(time.Hour), now.Add(2*time.Minute + 30*time.Second), true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.id, func(t *testing.T) {
+			now = tc.now
+			seen, err := mc.Seen(tc.id, tc.exp)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want, got := tc.seen, seen; got != want {
+				t.Errorf("jwtutil.MemoryCache.Seen mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+		})
+	}
+}
+
+func TestReplayValidator(t *testing.T) {
+	now := time.Now()
+	vd := jwtutil.ReplayValidator(jwtutil.NewMemoryCache(), now, time.Minute)
+	exp := jwt.NumericDate(now.Add(time.Hour))
+	testCases := []struct {
+		pl  *jwt.Payload
+		err error
+	}{
+		{&jwt.Payload{}, jwt.ErrJtiValidation},
+		{&jwt.Payload{JWTID: "foo", ExpirationTime: exp}, nil},
+		{&jwt.Payload{JWTID: "foo", ExpirationTime: exp}, jwtutil.ErrReplay},
+		{&jwt.Payload{JWTID: "bar"}, nil},
+		{&jwt.Payload{JWTID: "bar"}, jwtutil.ErrReplay},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.pl.JWTID, func(t *testing.T) {
+			if want, got := tc.err, vd(tc.pl); !internal.ErrorIs(got, want) {
+				t.Errorf(cmp.Diff(want, got))
+			}
+		})
+	}
+}
+
+func TestReplayValidatorTTL(t *testing.T) {
+	now := time.Now().Add(-time.Hour)
+	// The cache's clock is past the ttl, so identifiers without "exp" are already expired.
+	vd := jwtutil.ReplayValidator(jwtutil.NewMemoryCache(), now, time.Minute)
+	for i := 0; i < 2; i++ {
+		if err := vd(&jwt.Payload{JWTID: "foo"}); err != nil {
+			t.Errorf("jwtutil.ReplayValidator err mismatch (-want +got):\n%s", cmp.Diff(nil, err))
+		}
+	}
+}
@@ -6,13 +6,38 @@ import (
 )
 
 // Resolver is an Algorithm resolver.
+// It keeps the first algorithm it resolves, so it is meant to be used for a single token.
 type Resolver struct {
 	New func(jwt.Header) (jwt.Algorithm, error)
 	alg jwt.Algorithm
+
+	// single makes resolving again an error instead of a no-op.
+	single bool
 }
 
-// ErrNilAlg is the error for when an algorithm can't be resolved.
-var ErrNilAlg = internal.NewError("algorithm is nil")
+var (
+	// ErrNilAlg is the error for when an algorithm can't be resolved.
+	ErrNilAlg = internal.NewError("algorithm is nil")
+	// ErrResolverReused is the error for resolving another token with a Resolver created by NewResolver.
+	ErrResolverReused = internal.NewError("jwtutil: resolver has already been used")
+)
+
+// NewResolver creates a Resolver that builds the algorithm named by the "alg" header
+// with jwt.NewAlgorithm, using the key returned by key for the header, e.g. looked up by "kid".
+// Since the key's type is checked against the algorithm, a token can't pick an algorithm
+// its key wasn't meant for.
+//
+// The returned Resolver must only be used for a single token, which is enforced by failing
+// with ErrResolverReused when resolving again, so NewResolver should be called for each token.
+func NewResolver(key func(jwt.Header) (interface{}, error)) *Resolver {
+	return &Resolver{New: func(hd jwt.Header) (jwt.Algorithm, error) {
+		k, err := key(hd)
+		if err != nil {
+			return nil, err
+		}
+		return jwt.NewAlgorithm(hd.Algorithm, k)
+	}, single: true}
+}
 
 // Name returns an Algorithm's name.
 func (rv *Resolver) Name() string {
@@ -25,6 +50,9 @@ func (rv *Resolver) Name() string {
 // Resolve sets an Algorithm based on a JOSE Header.
 func (rv *Resolver) Resolve(hd jwt.Header) error {
 	if rv.alg != nil {
+		if rv.single {
+			return ErrResolverReused
+		}
 		return nil
 	}
 	if rv.New == nil {
@@ -1,11 +1,17 @@
 package jwtutil_test
 
 import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
 	"errors"
 	"testing"
 
 	"github.com/gbrlsnchs/jwt/v3"
+	"github.com/gbrlsnchs/jwt/v3/internal"
 	"github.com/gbrlsnchs/jwt/v3/jwtutil"
+	"github.com/google/go-cmp/cmp"
 )
 
 var hs256 = jwt.NewHS256([]byte("resolver"))
@@ -50,3 +56,66 @@ func TestResolver(t *testing.T) {
 		})
 	}
 }
+
+func TestNewResolver(t *testing.T) {
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	es256 := jwt.NewES256(jwt.ECDSAPrivateKey(priv))
+	keys := map[string]interface{}{
+		"ec":  &priv.PublicKey,
+		"oct": bytes.Repeat([]byte("k"), 32),
+	}
+	key := func(hd jwt.Header) (interface{}, error) {
+		k, ok := keys[hd.KeyID]
+		if !ok {
+			return nil, errors.New(`unknown "kid"`)
+		}
+		return k, nil
+	}
+	testCases := []struct {
+		name   string
+		signer jwt.Algorithm
+		kid    string
+		err    error
+	}{
+		{"ES256", es256, "ec", nil},
+		{"HS256", jwt.NewHS256(keys["oct"].([]byte)), "oct", nil},
+		{"algorithm confusion", jwt.NewHS256(elliptic.Marshal(elliptic.P256(), priv.X, priv.Y)), "ec", jwt.ErrInvalidKey},
+		{"unsupported algorithm", jwt.None(), "oct", jwt.ErrUnsupportedAlg},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			token, err := jwt.Sign(jwt.Payload{}, tc.signer, jwt.KeyID(tc.kid))
+			if err != nil {
+				t.Fatal(err)
+			}
+			var pl jwt.Payload
+			_, err = jwt.Verify(token, jwtutil.NewResolver(key), &pl)
+			if want, got := tc.err, err; !internal.ErrorIs(got, want) {
+				t.Errorf("jwt.Verify err mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+		})
+	}
+
+	t.Run("reused", func(t *testing.T) {
+		ecToken, err := jwt.Sign(jwt.Payload{}, es256, jwt.KeyID("ec"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		octToken, err := jwt.Sign(jwt.Payload{}, jwt.NewHS256(keys["oct"].([]byte)), jwt.KeyID("oct"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		rv := jwtutil.NewResolver(key)
+		var pl jwt.Payload
+		if _, err = jwt.Verify(ecToken, rv, &pl); err != nil {
+			t.Fatal(err)
+		}
+		_, err = jwt.Verify(octToken, rv, &pl)
+		if want, got := jwtutil.ErrResolverReused, err; !internal.ErrorIs(got, want) {
+			t.Errorf("jwt.Verify err mismatch (-want +got):\n%s", cmp.Diff(want, got))
+		}
+	})
+}
@@ -0,0 +1,233 @@
+package jwtutil
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"time"
+
+	"github.com/gbrlsnchs/jwt/v3"
+	"github.com/gbrlsnchs/jwt/v3/internal"
+)
+
+const (
+	defaultSessionCookie   = "session"
+	defaultSessionLifetime = time.Hour
+	defaultSessionMaxAge   = 24 * time.Hour
+	defaultCSRFHeader      = "X-CSRF-Token"
+)
+
+// ErrCSRFValidation is the error for a request whose CSRF token mismatches its session's.
+var ErrCSRFValidation = internal.NewError("jwtutil: csrf claim is invalid")
+
+// SessionToken is the payload of a session token.
+type SessionToken struct {
+	jwt.Payload
+	// CSRFToken must be sent in the Session.CSRFHeader HTTP header of unsafe requests.
+	// It is generated when issuing a session.
+	CSRFToken string `json:"csrf"`
+	// AuthTime is when the session was first issued. It is kept when re-issuing the session.
+	AuthTime *jwt.Time              `json:"auth_time,omitempty"`
+	Data     map[string]interface{} `json:"data,omitempty"`
+}
+
+// Session stores session tokens in cookies.
+// Cookies are always Secure and HttpOnly.
+type Session struct {
+	// Algorithm signs and verifies session tokens.
+	Algorithm jwt.Algorithm
+	// CookieName is the name of the session cookie. Defaults to "session".
+	CookieName string
+	// Domain and Path are the attributes of the session cookie.
+	Domain, Path string
+	// SameSite is the SameSite attribute of the session cookie. Defaults to http.SameSiteLaxMode.
+	SameSite http.SameSite
+	// Lifetime is how long a session token is valid. Defaults to 1 hour.
+	Lifetime time.Duration
+	// RefreshWithin re-issues session tokens expiring within this duration when they're verified,
+	// so active sessions don't expire. Defaults to half of Lifetime.
+	RefreshWithin time.Duration
+	// MaxAge is how long a session lasts since it was first issued, regardless of refreshes.
+	// Session tokens never expire past it. Defaults to 24 hours.
+	MaxAge time.Duration
+	// CSRFHeader is the HTTP header carrying the CSRF token. Defaults to "X-CSRF-Token".
+	CSRFHeader string
+	// Now returns the current time. Defaults to time.Now.
+	Now func() time.Time
+}
+
+// Issue signs st and sets it as the session cookie. The "iat" and "exp" claims are overridden and,
+// when not set, the "jti" and "auth_time" claims and the CSRF token are generated.
+func (s *Session) Issue(w http.ResponseWriter, st *SessionToken) error {
+	if s.Algorithm == nil {
+		return ErrNoAlgorithm
+	}
+	var err error
+	if st.JWTID == "" {
+		if st.JWTID, err = internal.RandomID(16); err != nil {
+			return err
+		}
+	}
+	if st.CSRFToken == "" {
+		if st.CSRFToken, err = internal.RandomID(32); err != nil {
+			return err
+		}
+	}
+	now := s.now()
+	if st.AuthTime == nil {
+		st.AuthTime = jwt.NumericDate(now)
+	}
+	st.IssuedAt = jwt.NumericDate(now)
+	st.ExpirationTime = jwt.NumericDate(s.expirationTime(st.AuthTime.Time, now))
+	token, err := jwt.Sign(st, s.Algorithm)
+	if err != nil {
+		return err
+	}
+	http.SetCookie(w, s.cookie(string(token), st.ExpirationTime.Time))
+	return nil
+}
+
+// Verify verifies the session token sent with r. For unsafe methods, the CSRF token must be sent
+// in the CSRFHeader HTTP header. When the session token expires soon, it is re-issued in w,
+// unless MaxAge has been reached. When s has no Algorithm, ErrNoAlgorithm is returned.
+func (s *Session) Verify(w http.ResponseWriter, r *http.Request) (jwt.Header, *SessionToken, error) {
+	var (
+		hd jwt.Header
+		st SessionToken
+	)
+	if s.Algorithm == nil {
+		return hd, nil, ErrNoAlgorithm
+	}
+	c, err := r.Cookie(s.cookieName())
+	if err != nil {
+		return hd, nil, ErrMissingToken
+	}
+	now := s.now()
+	hd, err = jwt.Verify([]byte(c.Value), s.Algorithm, &st,
+		jwt.ValidateHeader,
+		jwt.ValidatePayload(&st.Payload, jwt.ExpirationTimeValidator(now)),
+	)
+	if err != nil {
+		return hd, nil, err
+	}
+	if !isSafeMethod(r.Method) {
+		csrf := r.Header.Get(s.csrfHeader())
+		if csrf == "" || subtle.ConstantTimeCompare([]byte(csrf), []byte(st.CSRFToken)) != 1 {
+			return hd, nil, ErrCSRFValidation
+		}
+	}
+	if st.AuthTime != nil && st.ExpirationTime.Sub(now) < s.refreshWithin() &&
+		s.expirationTime(st.AuthTime.Time, now).After(st.ExpirationTime.Time) {
+		if err = s.Issue(w, &st); err != nil {
+			return hd, nil, err
+		}
+	}
+	return hd, &st, nil
+}
+
+// Clear removes the session cookie.
+func (s *Session) Clear(w http.ResponseWriter) {
+	c := s.cookie("", time.Unix(0, 0))
+	c.MaxAge = -1
+	http.SetCookie(w, c)
+}
+
+// Handler wraps next so that it only serves requests with a valid session.
+// The session token's header and payload are stored in the request's context.
+// Requests with an invalid CSRF token are answered with 403 Forbidden and
+// other invalid requests with 401 Unauthorized. When s has no Algorithm,
+// requests are answered with 500 Internal Server Error.
+func (s *Session) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hd, st, err := s.Verify(w, r)
+		if err != nil {
+			status := http.StatusUnauthorized
+			switch {
+			case internal.ErrorIs(err, ErrNoAlgorithm):
+				status = http.StatusInternalServerError
+			case internal.ErrorIs(err, ErrCSRFValidation):
+				status = http.StatusForbidden
+			}
+			http.Error(w, http.StatusText(status), status)
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(NewContext(r.Context(), hd, st)))
+	})
+}
+
+func (s *Session) cookie(value string, exp time.Time) *http.Cookie {
+	sameSite := s.SameSite
+	if sameSite == 0 {
+		sameSite = http.SameSiteLaxMode
+	}
+	return &http.Cookie{
+		Name:     s.cookieName(),
+		Value:    value,
+		Path:     s.Path,
+		Domain:   s.Domain,
+		Expires:  exp,
+		Secure:   true,
+		HttpOnly: true,
+		SameSite: sameSite,
+	}
+}
+
+func (s *Session) cookieName() string {
+	if s.CookieName == "" {
+		return defaultSessionCookie
+	}
+	return s.CookieName
+}
+
+func (s *Session) csrfHeader() string {
+	if s.CSRFHeader == "" {
+		return defaultCSRFHeader
+	}
+	return s.CSRFHeader
+}
+
+func (s *Session) lifetime() time.Duration {
+	if s.Lifetime == 0 {
+		return defaultSessionLifetime
+	}
+	return s.Lifetime
+}
+
+func (s *Session) maxAge() time.Duration {
+	if s.MaxAge == 0 {
+		return defaultSessionMaxAge
+	}
+	return s.MaxAge
+}
+
+// expirationTime returns when a session token issued at now expires,
+// which is never past MaxAge since authTime.
+func (s *Session) expirationTime(authTime, now time.Time) time.Time {
+	exp := now.Add(s.lifetime())
+	if max := authTime.Add(s.maxAge()); exp.After(max) {
+		return max
+	}
+	return exp
+}
+
+func (s *Session) refreshWithin() time.Duration {
+	if s.RefreshWithin == 0 {
+		return s.lifetime() / 2
+	}
+	return s.RefreshWithin
+}
+
+func (s *Session) now() time.Time {
+	if s.Now == nil {
+		return time.Now()
+	}
+	return s.Now()
+}
+
+// isSafeMethod checks whether method is safe, as per the RFC 7231.
+func isSafeMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,133 @@
+package jwtutil_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gbrlsnchs/jwt/v3"
+	"github.com/gbrlsnchs/jwt/v3/internal"
+	"github.com/gbrlsnchs/jwt/v3/jwtutil"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestSession(t *testing.T) {
+	now := time.Now()
+	testCases := []struct {
+		name     string
+		issuedAt time.Time
+		method   string
+		csrf     bool
+		status   int
+		refresh  bool
+	}{
+		{"safe method", now, http.MethodGet, false, http.StatusOK, false},
+		{"unsafe method", now, http.MethodPost, true, http.StatusOK, false},
+		{"missing CSRF token", now, http.MethodPost, false, http.StatusForbidden, false},
+		{"refresh", now.Add(-45 * time.Minute), http.MethodGet, false, http.StatusOK, true},
+		{"expired", now.Add(-2 * time.Hour), http.MethodGet, false, http.StatusUnauthorized, false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := &jwtutil.Session{
+				Algorithm: hs256,
+				Now:       func() time.Time { return tc.issuedAt },
+			}
+			st := &jwtutil.SessionToken{Payload: jwt.Payload{Subject: "someone"}}
+			w := httptest.NewRecorder()
+			if err := s.Issue(w, st); err != nil {
+				t.Fatal(err)
+			}
+			cookies := w.Result().Cookies()
+			if len(cookies) != 1 {
+				t.Fatalf("want 1 cookie, got %d", len(cookies))
+			}
+			if c := cookies[0]; !c.Secure || !c.HttpOnly || c.SameSite != http.SameSiteLaxMode {
+				t.Errorf("insecure cookie: %+v", c)
+			}
+
+			s.Now = func() time.Time { return now }
+			h := s.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got, ok := jwtutil.PayloadFromContext(r.Context()).(*jwtutil.SessionToken)
+				if !ok {
+					t.Fatalf("missing session in context")
+				}
+				if want, got := "someone", got.Subject; got != want {
+					t.Errorf("sub mismatch (-want +got):\n%s", cmp.Diff(want, got))
+				}
+			}))
+			r := httptest.NewRequest(tc.method, "/", nil)
+			r.AddCookie(cookies[0])
+			if tc.csrf {
+				r.Header.Set("X-CSRF-Token", st.CSRFToken)
+			}
+			w = httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+			if want, got := tc.status, w.Code; got != want {
+				t.Errorf("status mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+			if want, got := tc.refresh, len(w.Result().Cookies()) > 0; got != want {
+				t.Errorf("refresh mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+		})
+	}
+
+	t.Run("max age", func(t *testing.T) {
+		s := &jwtutil.Session{
+			Algorithm: hs256,
+			MaxAge:    50 * time.Minute,
+			Now:       func() time.Time { return now.Add(-45 * time.Minute) },
+		}
+		w := httptest.NewRecorder()
+		if err := s.Issue(w, &jwtutil.SessionToken{}); err != nil {
+			t.Fatal(err)
+		}
+		c := w.Result().Cookies()[0]
+		if want, got := now.Add(5*time.Minute).Unix(), c.Expires.Unix(); got != want {
+			t.Errorf("exp mismatch (-want +got):\n%s", cmp.Diff(want, got))
+		}
+		for _, tc := range []struct {
+			now    time.Time
+			status int
+		}{
+			{now, http.StatusOK},
+			{now.Add(10 * time.Minute), http.StatusUnauthorized},
+		} {
+			s.Now = func() time.Time { return tc.now }
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			r.AddCookie(c)
+			w = httptest.NewRecorder()
+			s.Handler(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {})).ServeHTTP(w, r)
+			if want, got := tc.status, w.Code; got != want {
+				t.Errorf("status mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+			if cookies := w.Result().Cookies(); len(cookies) > 0 {
+				t.Errorf("session refreshed past max age: %+v", cookies)
+			}
+		}
+	})
+
+	t.Run("no algorithm", func(t *testing.T) {
+		s := new(jwtutil.Session)
+		if want, got := jwtutil.ErrNoAlgorithm, s.Issue(httptest.NewRecorder(), &jwtutil.SessionToken{}); !internal.ErrorIs(got, want) {
+			t.Errorf("jwtutil.Session.Issue err mismatch (-want +got):\n%s", cmp.Diff(want, got))
+		}
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.AddCookie(&http.Cookie{Name: "session", Value: "token"})
+		w := httptest.NewRecorder()
+		s.Handler(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {})).ServeHTTP(w, r)
+		if want, got := http.StatusInternalServerError, w.Code; got != want {
+			t.Errorf("status mismatch (-want +got):\n%s", cmp.Diff(want, got))
+		}
+	})
+
+	t.Run("clear", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		new(jwtutil.Session).Clear(w)
+		cookies := w.Result().Cookies()
+		if len(cookies) != 1 || cookies[0].MaxAge >= 0 || cookies[0].Name != "session" {
+			t.Errorf("session cookie not cleared: %+v", cookies)
+		}
+	})
+}
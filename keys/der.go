@@ -0,0 +1,201 @@
+package keys
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"math/big"
+
+	"github.com/cloudflare/circl/sign/ed448"
+	"github.com/gbrlsnchs/jwt/v3"
+)
+
+// crypto/x509 only supports the curves of crypto/elliptic and Ed25519, so secp256k1 and Ed448 keys
+// are encoded and parsed here, as per the SEC 1, the RFC 5480 and the RFC 8410.
+var (
+	oidPublicKeyECDSA = asn1.ObjectIdentifier{1, 2, 840, 10045, 2, 1}
+	oidSecp256k1      = asn1.ObjectIdentifier{1, 3, 132, 0, 10}
+	oidEd448          = asn1.ObjectIdentifier{1, 3, 101, 113}
+)
+
+type pkcs8 struct {
+	Version    int
+	Algo       pkix.AlgorithmIdentifier
+	PrivateKey []byte
+}
+
+type spki struct {
+	Algo      pkix.AlgorithmIdentifier
+	PublicKey asn1.BitString
+}
+
+type ecPrivateKey struct {
+	Version       int
+	PrivateKey    []byte
+	NamedCurveOID asn1.ObjectIdentifier `asn1:"optional,explicit,tag:0"`
+	PublicKey     asn1.BitString        `asn1:"optional,explicit,tag:1"`
+}
+
+func isSecp256k1(curve elliptic.Curve) bool {
+	return curve == jwt.Secp256k1()
+}
+
+func secp256k1Algo() (pkix.AlgorithmIdentifier, error) {
+	params, err := asn1.Marshal(oidSecp256k1)
+	if err != nil {
+		return pkix.AlgorithmIdentifier{}, err
+	}
+	return pkix.AlgorithmIdentifier{Algorithm: oidPublicKeyECDSA, Parameters: asn1.RawValue{FullBytes: params}}, nil
+}
+
+func isSecp256k1Algo(algo pkix.AlgorithmIdentifier) bool {
+	var oid asn1.ObjectIdentifier
+	if !algo.Algorithm.Equal(oidPublicKeyECDSA) {
+		return false
+	}
+	_, err := asn1.Unmarshal(algo.Parameters.FullBytes, &oid)
+	return err == nil && oid.Equal(oidSecp256k1)
+}
+
+func marshalPKCS8(priv crypto.PrivateKey) ([]byte, error) {
+	var (
+		p   pkcs8
+		err error
+	)
+	switch priv := priv.(type) {
+	case *ecdsa.PrivateKey:
+		if !isSecp256k1(priv.Curve) {
+			break
+		}
+		if p.Algo, err = secp256k1Algo(); err != nil {
+			return nil, err
+		}
+		// Like crypto/x509, the curve is only set in the algorithm identifier.
+		if p.PrivateKey, err = marshalSecp256k1(priv, nil); err != nil {
+			return nil, err
+		}
+		return asn1.Marshal(p)
+	case ed448.PrivateKey:
+		p.Algo = pkix.AlgorithmIdentifier{Algorithm: oidEd448}
+		if p.PrivateKey, err = asn1.Marshal(priv.Seed()); err != nil {
+			return nil, err
+		}
+		return asn1.Marshal(p)
+	}
+	return x509.MarshalPKCS8PrivateKey(priv)
+}
+
+func parsePKCS8(der []byte) (interface{}, error) {
+	var p pkcs8
+	if _, err := asn1.Unmarshal(der, &p); err == nil {
+		switch {
+		case isSecp256k1Algo(p.Algo):
+			return parseSecp256k1(p.PrivateKey)
+		case p.Algo.Algorithm.Equal(oidEd448):
+			var seed []byte
+			if _, err = asn1.Unmarshal(p.PrivateKey, &seed); err != nil {
+				return nil, err
+			}
+			if len(seed) != ed448.SeedSize {
+				return nil, ErrMalformedKey
+			}
+			return ed448.NewKeyFromSeed(seed), nil
+		}
+	}
+	return x509.ParsePKCS8PrivateKey(der)
+}
+
+func marshalSEC1(priv *ecdsa.PrivateKey) ([]byte, error) {
+	if isSecp256k1(priv.Curve) {
+		return marshalSecp256k1(priv, oidSecp256k1)
+	}
+	return x509.MarshalECPrivateKey(priv)
+}
+
+func parseSEC1(der []byte) (*ecdsa.PrivateKey, error) {
+	var key ecPrivateKey
+	if _, err := asn1.Unmarshal(der, &key); err == nil && key.NamedCurveOID.Equal(oidSecp256k1) {
+		return parseSecp256k1(der)
+	}
+	return x509.ParseECPrivateKey(der)
+}
+
+func marshalSPKI(pub crypto.PublicKey) ([]byte, error) {
+	var (
+		p   spki
+		err error
+	)
+	switch pub := pub.(type) {
+	case *ecdsa.PublicKey:
+		if !isSecp256k1(pub.Curve) {
+			break
+		}
+		if p.Algo, err = secp256k1Algo(); err != nil {
+			return nil, err
+		}
+		point := elliptic.Marshal(pub.Curve, pub.X, pub.Y)
+		p.PublicKey = asn1.BitString{Bytes: point, BitLength: 8 * len(point)}
+		return asn1.Marshal(p)
+	case ed448.PublicKey:
+		p.Algo = pkix.AlgorithmIdentifier{Algorithm: oidEd448}
+		p.PublicKey = asn1.BitString{Bytes: pub, BitLength: 8 * len(pub)}
+		return asn1.Marshal(p)
+	}
+	return x509.MarshalPKIXPublicKey(pub)
+}
+
+func parseSPKI(der []byte) (interface{}, error) {
+	var p spki
+	if _, err := asn1.Unmarshal(der, &p); err == nil {
+		switch {
+		case isSecp256k1Algo(p.Algo):
+			curve := jwt.Secp256k1()
+			x, y := elliptic.Unmarshal(curve, p.PublicKey.RightAlign())
+			if x == nil {
+				return nil, ErrMalformedKey
+			}
+			return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+		case p.Algo.Algorithm.Equal(oidEd448):
+			if p.PublicKey.BitLength != 8*ed448.PublicKeySize {
+				return nil, ErrMalformedKey
+			}
+			return append(ed448.PublicKey(nil), p.PublicKey.Bytes...), nil
+		}
+	}
+	return x509.ParsePKIXPublicKey(der)
+}
+
+func marshalSecp256k1(priv *ecdsa.PrivateKey, oid asn1.ObjectIdentifier) ([]byte, error) {
+	// The private key is padded to the size of the curve's order.
+	d := make([]byte, (priv.Params().N.BitLen()+7)/8)
+	b := priv.D.Bytes()
+	copy(d[len(d)-len(b):], b)
+	point := elliptic.Marshal(priv.Curve, priv.X, priv.Y)
+	return asn1.Marshal(ecPrivateKey{
+		Version:       1,
+		PrivateKey:    d,
+		NamedCurveOID: oid,
+		PublicKey:     asn1.BitString{Bytes: point, BitLength: 8 * len(point)},
+	})
+}
+
+func parseSecp256k1(der []byte) (*ecdsa.PrivateKey, error) {
+	var key ecPrivateKey
+	if _, err := asn1.Unmarshal(der, &key); err != nil {
+		return nil, err
+	}
+	if key.NamedCurveOID != nil && !key.NamedCurveOID.Equal(oidSecp256k1) {
+		return nil, ErrMalformedKey
+	}
+	curve := jwt.Secp256k1()
+	d := new(big.Int).SetBytes(key.PrivateKey)
+	if key.Version != 1 || d.Sign() <= 0 || d.Cmp(curve.Params().N) >= 0 {
+		return nil, ErrMalformedKey
+	}
+	priv := &ecdsa.PrivateKey{PublicKey: ecdsa.PublicKey{Curve: curve}, D: d}
+	priv.X, priv.Y = curve.ScalarBaseMult(key.PrivateKey)
+	return priv, nil
+}
@@ -0,0 +1,15 @@
+// +build !go1.13
+
+package keys
+
+import (
+	"crypto/rand"
+
+	"golang.org/x/crypto/ed25519"
+)
+
+// GenerateEd25519 generates an Ed25519 key.
+func GenerateEd25519() (ed25519.PrivateKey, error) {
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	return priv, err
+}
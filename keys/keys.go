@@ -0,0 +1,102 @@
+// Package keys generates, parses and encodes keys for signing and verifying JWTs.
+package keys
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+
+	"github.com/cloudflare/circl/sign/ed448"
+	"github.com/gbrlsnchs/jwt/v3"
+	"github.com/gbrlsnchs/jwt/v3/internal"
+)
+
+// MinRSABits is the minimum size of RSA keys, as per the RFC 7518.
+const MinRSABits = 2048
+
+var (
+	// ErrUnsupportedAlg is the error for an algorithm keys can't be generated or used for.
+	ErrUnsupportedAlg = internal.NewError("keys: unsupported algorithm")
+	// ErrUnsupportedKey is the error for a key of an unsupported type.
+	ErrUnsupportedKey = internal.NewError("keys: unsupported key")
+	// ErrKeyTooShort is the error for an RSA key smaller than MinRSABits.
+	ErrKeyTooShort = internal.NewError("keys: key is too short")
+)
+
+// GenerateRSA generates an RSA key of bits size, which must be at least MinRSABits.
+func GenerateRSA(bits int) (*rsa.PrivateKey, error) {
+	if bits < MinRSABits {
+		return nil, ErrKeyTooShort
+	}
+	return rsa.GenerateKey(rand.Reader, bits)
+}
+
+// GenerateECDSA generates an ECDSA key using curve c.
+func GenerateECDSA(c elliptic.Curve) (*ecdsa.PrivateKey, error) {
+	return ecdsa.GenerateKey(c, rand.Reader)
+}
+
+// GenerateEd448 generates an Ed448 key.
+func GenerateEd448() (ed448.PrivateKey, error) {
+	_, priv, err := ed448.GenerateKey(rand.Reader)
+	return priv, err
+}
+
+// GenerateSecret generates a random HMAC secret as long as the output of h, as per the RFC 7518.
+func GenerateSecret(h crypto.Hash) ([]byte, error) {
+	secret := make([]byte, h.Size())
+	if _, err := rand.Read(secret); err != nil {
+		return nil, err
+	}
+	return secret, nil
+}
+
+// Generate generates a key for the algorithm called alg. HMAC algorithms get a []byte secret,
+// RSA algorithms get a key of MinRSABits size, ECDSA algorithms get a key on their curve
+// and EdDSA gets an Ed25519 key.
+func Generate(alg string) (crypto.PrivateKey, error) {
+	switch alg {
+	case "HS256":
+		return GenerateSecret(crypto.SHA256)
+	case "HS384":
+		return GenerateSecret(crypto.SHA384)
+	case "HS512":
+		return GenerateSecret(crypto.SHA512)
+	case "RS256", "RS384", "RS512", "PS256", "PS384", "PS512":
+		return GenerateRSA(MinRSABits)
+	case "ES256":
+		return GenerateECDSA(elliptic.P256())
+	case "ES384":
+		return GenerateECDSA(elliptic.P384())
+	case "ES512":
+		return GenerateECDSA(elliptic.P521())
+	case "ES256K":
+		return GenerateECDSA(jwt.Secp256k1())
+	case "EdDSA":
+		return GenerateEd25519()
+	}
+	return nil, internal.Errorf("keys: %q: %w", alg, ErrUnsupportedAlg)
+}
+
+// GenerateAlgorithm generates a key for the algorithm called alg and returns the algorithm using it.
+func GenerateAlgorithm(alg string) (jwt.Algorithm, error) {
+	key, err := Generate(alg)
+	if err != nil {
+		return nil, err
+	}
+	return Algorithm(alg, key)
+}
+
+// Algorithm returns the algorithm called alg using key, which may be a []byte secret,
+// a private key, which signs and verifies, or a public key, which only verifies.
+// Keys are validated by jwt.NewAlgorithm, so short secrets, RSA keys smaller than MinRSABits
+// and keys on the wrong curve are rejected.
+func Algorithm(alg string, key interface{}) (jwt.Algorithm, error) {
+	a, err := jwt.NewAlgorithm(alg, key)
+	if internal.ErrorIs(err, jwt.ErrUnsupportedAlg) || internal.ErrorIs(err, jwt.ErrInvalidKey) {
+		return nil, internal.Errorf("keys: %q: %w", alg, ErrUnsupportedKey)
+	}
+	return a, err
+}
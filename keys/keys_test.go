@@ -0,0 +1,128 @@
+package keys_test
+
+import (
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"testing"
+
+	"github.com/gbrlsnchs/jwt/v3"
+	"github.com/gbrlsnchs/jwt/v3/internal"
+	"github.com/gbrlsnchs/jwt/v3/keys"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestGenerateAlgorithm(t *testing.T) {
+	testCases := []string{
+		"HS256", "HS384", "HS512",
+		"RS256", "RS384", "RS512",
+		"PS256", "PS384", "PS512",
+		"ES256", "ES384", "ES512",
+		"ES256K",
+		"EdDSA",
+	}
+	for _, name := range testCases {
+		t.Run(name, func(t *testing.T) {
+			alg, err := keys.GenerateAlgorithm(name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want, got := name, alg.Name(); got != want {
+				t.Errorf("alg mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+			token, err := jwt.Sign(jwt.Payload{Subject: "someone"}, alg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var pl jwt.Payload
+			if _, err = jwt.Verify(token, alg, &pl, jwt.ValidateHeader); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+
+	t.Run("unsupported", func(t *testing.T) {
+		_, err := keys.GenerateAlgorithm("none")
+		if want, got := keys.ErrUnsupportedAlg, err; !internal.ErrorIs(got, want) {
+			t.Errorf("keys.GenerateAlgorithm err mismatch (-want +got):\n%s", cmp.Diff(want, got))
+		}
+	})
+}
+
+func TestGenerateSecret(t *testing.T) {
+	testCases := []struct {
+		alg  string
+		size int
+	}{
+		{"HS256", 32},
+		{"HS384", 48},
+		{"HS512", 64},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.alg, func(t *testing.T) {
+			key, err := keys.Generate(tc.alg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want, got := tc.size, len(key.([]byte)); got != want {
+				t.Errorf("secret size mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+		})
+	}
+}
+
+func TestGenerateRSA(t *testing.T) {
+	_, err := keys.GenerateRSA(1024)
+	if want, got := keys.ErrKeyTooShort, err; !internal.ErrorIs(got, want) {
+		t.Errorf("keys.GenerateRSA err mismatch (-want +got):\n%s", cmp.Diff(want, got))
+	}
+}
+
+func TestGenerateEd448(t *testing.T) {
+	priv, err := keys.GenerateEd448()
+	if err != nil {
+		t.Fatal(err)
+	}
+	alg, err := keys.Algorithm("EdDSA", priv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := alg.(*jwt.Ed448); !ok {
+		t.Errorf("keys.Algorithm returned %T instead of *jwt.Ed448", alg)
+	}
+}
+
+func TestAlgorithm(t *testing.T) {
+	p256, err := keys.GenerateECDSA(elliptic.P256())
+	if err != nil {
+		t.Fatal(err)
+	}
+	rsa1024, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testCases := []struct {
+		name string
+		alg  string
+		key  interface{}
+		err  error
+	}{
+		{"private key", "ES256", p256, nil},
+		{"public key", "ES256", &p256.PublicKey, nil},
+		{"curve mismatch", "ES384", p256, jwt.ErrECDSACurve},
+		{"short RSA key", "RS256", rsa1024, jwt.ErrRSAKeyTooShort},
+		{"short RSA public key", "PS256", &rsa1024.PublicKey, jwt.ErrRSAKeyTooShort},
+		{"short secret", "HS256", []byte("secret"), jwt.ErrHMACKeyTooShort},
+		{"family mismatch", "RS256", p256, keys.ErrUnsupportedKey},
+		{"secret mismatch", "ES256", []byte("secret"), keys.ErrUnsupportedKey},
+		{"unsupported key", "ES256", "key", keys.ErrUnsupportedKey},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := keys.Algorithm(tc.alg, tc.key)
+			if want, got := tc.err, err; !internal.ErrorIs(got, want) {
+				t.Errorf("keys.Algorithm err mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+		})
+	}
+}
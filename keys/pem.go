@@ -0,0 +1,104 @@
+package keys
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+
+	"github.com/gbrlsnchs/jwt/v3"
+	"github.com/gbrlsnchs/jwt/v3/internal"
+)
+
+var (
+	// ErrNoPEM is the error for data not containing a PEM block.
+	ErrNoPEM = internal.NewError("keys: no PEM block found")
+	// ErrMalformedKey is the error for an encoded key whose contents are invalid.
+	ErrMalformedKey = internal.NewError("keys: malformed key")
+)
+
+// PEM block types.
+const (
+	PKCS1PrivateKeyType = "RSA PRIVATE KEY"
+	PKCS1PublicKeyType  = "RSA PUBLIC KEY"
+	PKCS8PrivateKeyType = "PRIVATE KEY"
+	SEC1PrivateKeyType  = "EC PRIVATE KEY"
+	SPKIPublicKeyType   = "PUBLIC KEY"
+	CertificateType     = "CERTIFICATE"
+)
+
+// ParsePEM parses the first PEM block in b. It supports PKCS #1, PKCS #8 and SEC 1 private keys,
+// PKCS #1 and SPKI public keys and, for verification purposes, the public key of X.509 certificates.
+// Besides the keys supported by crypto/x509, secp256k1 and Ed448 keys are supported.
+func ParsePEM(b []byte) (interface{}, error) {
+	p, _ := pem.Decode(b)
+	if p == nil {
+		return nil, ErrNoPEM
+	}
+	switch p.Type {
+	case PKCS1PrivateKeyType:
+		return x509.ParsePKCS1PrivateKey(p.Bytes)
+	case PKCS1PublicKeyType:
+		return x509.ParsePKCS1PublicKey(p.Bytes)
+	case PKCS8PrivateKeyType:
+		return parsePKCS8(p.Bytes)
+	case SEC1PrivateKeyType:
+		return parseSEC1(p.Bytes)
+	case SPKIPublicKeyType:
+		return parseSPKI(p.Bytes)
+	case CertificateType:
+		cert, err := x509.ParseCertificate(p.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		return cert.PublicKey, nil
+	}
+	return nil, internal.Errorf("keys: %q: %w", p.Type, ErrUnsupportedKey)
+}
+
+// ParseAlgorithm parses the first PEM block in b and returns the algorithm called alg using its key.
+func ParseAlgorithm(alg string, b []byte) (jwt.Algorithm, error) {
+	key, err := ParsePEM(b)
+	if err != nil {
+		return nil, err
+	}
+	return Algorithm(alg, key)
+}
+
+// EncodePKCS1PrivateKey encodes priv as a PKCS #1 PEM block.
+func EncodePKCS1PrivateKey(priv *rsa.PrivateKey) []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: PKCS1PrivateKeyType, Bytes: x509.MarshalPKCS1PrivateKey(priv)})
+}
+
+// EncodePKCS1PublicKey encodes pub as a PKCS #1 PEM block.
+func EncodePKCS1PublicKey(pub *rsa.PublicKey) []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: PKCS1PublicKeyType, Bytes: x509.MarshalPKCS1PublicKey(pub)})
+}
+
+// EncodePKCS8PrivateKey encodes priv as a PKCS #8 PEM block.
+func EncodePKCS8PrivateKey(priv crypto.PrivateKey) ([]byte, error) {
+	der, err := marshalPKCS8(priv)
+	if err != nil {
+		return nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: PKCS8PrivateKeyType, Bytes: der}), nil
+}
+
+// EncodeSEC1PrivateKey encodes priv as a SEC 1 PEM block.
+func EncodeSEC1PrivateKey(priv *ecdsa.PrivateKey) ([]byte, error) {
+	der, err := marshalSEC1(priv)
+	if err != nil {
+		return nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: SEC1PrivateKeyType, Bytes: der}), nil
+}
+
+// EncodeSPKIPublicKey encodes pub as an SPKI PEM block.
+func EncodeSPKIPublicKey(pub crypto.PublicKey) ([]byte, error) {
+	der, err := marshalSPKI(pub)
+	if err != nil {
+		return nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: SPKIPublicKeyType, Bytes: der}), nil
+}
@@ -0,0 +1,42 @@
+// +build go1.13
+
+package keys_test
+
+import (
+	"testing"
+
+	"github.com/gbrlsnchs/jwt/v3"
+	"github.com/gbrlsnchs/jwt/v3/keys"
+)
+
+// Ed25519 keys are only supported by crypto/x509 since Go 1.13.
+func TestEd25519PEM(t *testing.T) {
+	priv, err := keys.GenerateEd25519()
+	if err != nil {
+		t.Fatal(err)
+	}
+	privPEM, err := keys.EncodePKCS8PrivateKey(priv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pubPEM, err := keys.EncodeSPKIPublicKey(priv.Public())
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer, err := keys.ParseAlgorithm("EdDSA", privPEM)
+	if err != nil {
+		t.Fatal(err)
+	}
+	verifier, err := keys.ParseAlgorithm("EdDSA", pubPEM)
+	if err != nil {
+		t.Fatal(err)
+	}
+	token, err := jwt.Sign(jwt.Payload{}, signer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var pl jwt.Payload
+	if _, err = jwt.Verify(token, verifier, &pl); err != nil {
+		t.Fatal(err)
+	}
+}
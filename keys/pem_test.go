@@ -0,0 +1,98 @@
+package keys_test
+
+import (
+	"crypto/elliptic"
+	"crypto/rsa"
+	"testing"
+
+	"github.com/gbrlsnchs/jwt/v3"
+	"github.com/gbrlsnchs/jwt/v3/internal"
+	"github.com/gbrlsnchs/jwt/v3/keys"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestPEM(t *testing.T) {
+	rsaKey, err := keys.GenerateRSA(keys.MinRSABits)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecKey, err := keys.GenerateECDSA(elliptic.P384())
+	if err != nil {
+		t.Fatal(err)
+	}
+	secp256k1Key, err := keys.GenerateECDSA(jwt.Secp256k1())
+	if err != nil {
+		t.Fatal(err)
+	}
+	ed448Key, err := keys.GenerateEd448()
+	if err != nil {
+		t.Fatal(err)
+	}
+	must := func(b []byte, err error) []byte {
+		if err != nil {
+			t.Fatal(err)
+		}
+		return b
+	}
+	testCases := []struct {
+		name   string
+		alg    string
+		signer interface{}
+		pem    []byte
+		public bool
+	}{
+		{"PKCS #1 private key", "RS256", rsaKey, keys.EncodePKCS1PrivateKey(rsaKey), false},
+		{"PKCS #1 public key", "PS256", rsaKey, keys.EncodePKCS1PublicKey(&rsaKey.PublicKey), true},
+		{"PKCS #8 RSA private key", "RS512", rsaKey, must(keys.EncodePKCS8PrivateKey(rsaKey)), false},
+		{"PKCS #8 ECDSA private key", "ES384", ecKey, must(keys.EncodePKCS8PrivateKey(ecKey)), false},
+		{"SEC 1 private key", "ES384", ecKey, must(keys.EncodeSEC1PrivateKey(ecKey)), false},
+		{"SPKI RSA public key", "RS384", rsaKey, must(keys.EncodeSPKIPublicKey(&rsaKey.PublicKey)), true},
+		{"SPKI ECDSA public key", "ES384", ecKey, must(keys.EncodeSPKIPublicKey(&ecKey.PublicKey)), true},
+		{"PKCS #8 secp256k1 private key", "ES256K", secp256k1Key, must(keys.EncodePKCS8PrivateKey(secp256k1Key)), false},
+		{"SEC 1 secp256k1 private key", "ES256K", secp256k1Key, must(keys.EncodeSEC1PrivateKey(secp256k1Key)), false},
+		{"SPKI secp256k1 public key", "ES256K", secp256k1Key, must(keys.EncodeSPKIPublicKey(&secp256k1Key.PublicKey)), true},
+		{"PKCS #8 Ed448 private key", "EdDSA", ed448Key, must(keys.EncodePKCS8PrivateKey(ed448Key)), false},
+		{"SPKI Ed448 public key", "EdDSA", ed448Key, must(keys.EncodeSPKIPublicKey(ed448Key.Public())), true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			signer, err := keys.Algorithm(tc.alg, tc.signer)
+			if err != nil {
+				t.Fatal(err)
+			}
+			alg, err := keys.ParseAlgorithm(tc.alg, tc.pem)
+			if err != nil {
+				t.Fatal(err)
+			}
+			token, err := jwt.Sign(jwt.Payload{}, signer)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var pl jwt.Payload
+			if _, err = jwt.Verify(token, alg, &pl); err != nil {
+				t.Fatal(err)
+			}
+			_, err = jwt.Sign(jwt.Payload{}, alg)
+			if want, got := tc.public, err != nil; got != want {
+				t.Errorf("public key mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+		})
+	}
+
+	t.Run("PKCS #1 key type", func(t *testing.T) {
+		key, err := keys.ParsePEM(keys.EncodePKCS1PublicKey(&rsaKey.PublicKey))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, ok := key.(*rsa.PublicKey); !ok {
+			t.Errorf("want *rsa.PublicKey, got %T", key)
+		}
+	})
+
+	t.Run("no PEM", func(t *testing.T) {
+		_, err := keys.ParsePEM([]byte("not a PEM block"))
+		if want, got := keys.ErrNoPEM, err; !internal.ErrorIs(got, want) {
+			t.Errorf("keys.ParsePEM err mismatch (-want +got):\n%s", cmp.Diff(want, got))
+		}
+	})
+}
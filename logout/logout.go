@@ -0,0 +1,119 @@
+// Package logout implements logout tokens for OpenID Connect Back-Channel Logout
+// and the parameters of OpenID Connect Front-Channel Logout requests.
+package logout
+
+import (
+	"encoding/json"
+	"net/url"
+	"time"
+
+	"github.com/gbrlsnchs/jwt/v3"
+	"github.com/gbrlsnchs/jwt/v3/internal"
+)
+
+const (
+	// Type is the media type for logout tokens.
+	Type = "logout+jwt"
+	// BackChannelEvent is the event identifier of a back-channel logout.
+	BackChannelEvent = "http://schemas.openid.net/event/backchannel-logout"
+
+	defaultLifetime = 2 * time.Minute
+)
+
+var (
+	// ErrEventsValidation is the error for an "events" claim not containing BackChannelEvent.
+	ErrEventsValidation = internal.NewError("logout: events claim is invalid")
+	// ErrSidValidation is the error for an invalid "sid" claim.
+	ErrSidValidation = internal.NewError("logout: sid claim is invalid")
+	// ErrNonceNotAllowed is the error for a logout token containing the "nonce" claim,
+	// which would allow it to be confused with an ID token.
+	ErrNonceNotAllowed = internal.NewError("logout: nonce claim is not allowed")
+)
+
+// Token is a logout token payload.
+type Token struct {
+	jwt.Payload
+	SessionID string                     `json:"sid,omitempty"`
+	Events    map[string]json.RawMessage `json:"events"`
+	// Nonce holds the raw "nonce" claim, which logout tokens must not contain whatever its value.
+	Nonce json.RawMessage `json:"nonce,omitempty"`
+}
+
+// NewToken signs a logout token issued by iss for the client identified by aud.
+// At least one of sub and sid must be set. The token expires in 2 minutes.
+func NewToken(iss, aud, sub, sid string, alg jwt.Algorithm, opts ...jwt.SignOption) ([]byte, error) {
+	if sub == "" && sid == "" {
+		return nil, ErrSidValidation
+	}
+	jti, err := internal.RandomID(16)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	lt := Token{
+		Payload: jwt.Payload{
+			Issuer:         iss,
+			Subject:        sub,
+			Audience:       jwt.Audience{aud},
+			ExpirationTime: jwt.NumericDate(now.Add(defaultLifetime)),
+			IssuedAt:       jwt.NumericDate(now),
+			JWTID:          jti,
+		},
+		SessionID: sid,
+		Events:    map[string]json.RawMessage{BackChannelEvent: json.RawMessage("{}")},
+	}
+	return jwt.Sign(lt, alg, append(opts[:len(opts):len(opts)], jwt.Type(Type))...)
+}
+
+// TokenValidator validates the claims required and prohibited in a logout token.
+func TokenValidator(lt *Token) jwt.Validator {
+	return func(pl *jwt.Payload) error {
+		switch {
+		case pl.Issuer == "":
+			return jwt.ErrIssValidation
+		case pl.IssuedAt == nil:
+			return jwt.ErrIatValidation
+		case pl.JWTID == "":
+			return jwt.ErrJtiValidation
+		case pl.Subject == "" && lt.SessionID == "":
+			return ErrSidValidation
+		case lt.Nonce != nil:
+			return ErrNonceNotAllowed
+		}
+		ev, ok := lt.Events[BackChannelEvent]
+		if !ok {
+			return ErrEventsValidation
+		}
+		var v map[string]json.RawMessage
+		if err := json.Unmarshal(ev, &v); err != nil || v == nil {
+			return ErrEventsValidation
+		}
+		return nil
+	}
+}
+
+// SessionIDValidator validates the "sid" claim.
+func SessionIDValidator(lt *Token, sid string) jwt.Validator {
+	return func(_ *jwt.Payload) error {
+		if lt.SessionID != sid {
+			return ErrSidValidation
+		}
+		return nil
+	}
+}
+
+// FrontChannelRequest validates the "iss" and "sid" parameters of a front-channel logout request
+// against the issuer and session identifier of the client's session.
+// Both parameters are optional, but when one of them is present, so must be the other.
+func FrontChannelRequest(query url.Values, iss, sid string) error {
+	gotIss, gotSid := query.Get("iss"), query.Get("sid")
+	switch {
+	case gotIss == "" && gotSid == "":
+		return nil
+	case gotIss != iss:
+		return jwt.ErrIssValidation
+	case gotSid != sid:
+		return ErrSidValidation
+	}
+	return nil
+}
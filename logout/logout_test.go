@@ -0,0 +1,81 @@
+package logout_test
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/gbrlsnchs/jwt/v3"
+	"github.com/gbrlsnchs/jwt/v3/internal"
+	"github.com/gbrlsnchs/jwt/v3/logout"
+	"github.com/google/go-cmp/cmp"
+)
+
+const (
+	issuer   = "https://server.example.com"
+	clientID = "s6BhdRkqt3"
+)
+
+var hs256 = jwt.NewHS256([]byte("logout"))
+
+func TestNewToken(t *testing.T) {
+	testCases := []struct {
+		name string
+		sub  string
+		sid  string
+		opts []jwt.SignOption
+		err  error
+	}{
+		{"sub", "248289761001", "", nil, nil},
+		{"sid", "", "08a5019c-17e1-4977-8f42-65a12843ea02", nil, nil},
+		{"sub and sid", "248289761001", "08a5019c-17e1-4977-8f42-65a12843ea02", nil, nil},
+		{"conflicting type", "248289761001", "", []jwt.SignOption{jwt.Type("JWT")}, nil},
+		{"neither sub nor sid", "", "", nil, logout.ErrSidValidation},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			token, err := logout.NewToken(issuer, clientID, tc.sub, tc.sid, hs256, tc.opts...)
+			if want, got := tc.err, err; !internal.ErrorIs(got, want) {
+				t.Fatalf("logout.NewToken err mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+			if err != nil {
+				return
+			}
+			var lt logout.Token
+			hd, err := jwt.Verify(token, hs256, &lt)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want, got := logout.Type, hd.Type; got != want {
+				t.Errorf("typ mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+			if want, got := tc.sid, lt.SessionID; got != want {
+				t.Errorf("sid mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+			if _, ok := lt.Events[logout.BackChannelEvent]; !ok {
+				t.Errorf("missing %q event", logout.BackChannelEvent)
+			}
+		})
+	}
+}
+
+func TestFrontChannelRequest(t *testing.T) {
+	testCases := []struct {
+		name  string
+		query url.Values
+		err   error
+	}{
+		{"no parameters", url.Values{}, nil},
+		{"valid", url.Values{"iss": {issuer}, "sid": {"sid"}}, nil},
+		{"wrong issuer", url.Values{"iss": {"https://other.example.com"}, "sid": {"sid"}}, jwt.ErrIssValidation},
+		{"wrong sid", url.Values{"iss": {issuer}, "sid": {"other"}}, logout.ErrSidValidation},
+		{"missing sid", url.Values{"iss": {issuer}}, logout.ErrSidValidation},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := logout.FrontChannelRequest(tc.query, issuer, "sid")
+			if want, got := tc.err, err; !internal.ErrorIs(got, want) {
+				t.Errorf("logout.FrontChannelRequest err mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package logout
+
+import (
+	"time"
+
+	"github.com/gbrlsnchs/jwt/v3"
+	"github.com/gbrlsnchs/jwt/v3/jwtutil"
+)
+
+// Verifier verifies logout tokens received by a client.
+type Verifier struct {
+	// Issuer is the OpenID Provider's issuer identifier.
+	Issuer string
+	// ClientID is the client's identifier, which the "aud" claim must contain.
+	ClientID string
+	// RequireType rejects logout tokens whose "typ" header is not Type.
+	RequireType bool
+	// Cache records the "jti" of accepted logout tokens so they can only be used once.
+	// When nil, replays are not detected.
+	Cache jwtutil.ReplayCache
+	// Now returns the current time. Defaults to time.Now.
+	Now func() time.Time
+}
+
+// Verify verifies token with alg and decodes it into lt. Validators in vds
+// run after the logout token's claims have been validated.
+func (v *Verifier) Verify(token []byte, alg jwt.Algorithm, lt *Token, vds ...jwt.Validator) (jwt.Header, error) {
+	now := time.Now()
+	if v.Now != nil {
+		now = v.Now()
+	}
+	vds = append([]jwt.Validator{
+		TokenValidator(lt),
+		jwt.IssuerValidator(v.Issuer),
+		jwt.AudienceValidator(jwt.Audience{v.ClientID}),
+		jwt.ExpirationTimeValidator(now),
+		jwt.IssuedAtValidator(now),
+	}, vds...)
+	if v.Cache != nil {
+		vds = append(vds, jwtutil.ReplayValidator(v.Cache, now, defaultLifetime))
+	}
+	opts := []jwt.VerifyOption{jwt.ValidateHeader, jwt.ValidatePayload(&lt.Payload, vds...)}
+	if v.RequireType {
+		opts = append(opts, jwt.ValidateType(Type))
+	}
+	return jwt.Verify(token, alg, lt, opts...)
+}
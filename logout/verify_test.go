@@ -0,0 +1,182 @@
+package logout_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/gbrlsnchs/jwt/v3"
+	"github.com/gbrlsnchs/jwt/v3/internal"
+	"github.com/gbrlsnchs/jwt/v3/jwtutil"
+	"github.com/gbrlsnchs/jwt/v3/logout"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestVerify(t *testing.T) {
+	now := time.Now()
+	valid := func() logout.Token {
+		return logout.Token{
+			Payload: jwt.Payload{
+				Issuer:         issuer,
+				Subject:        "248289761001",
+				Audience:       jwt.Audience{clientID},
+				ExpirationTime: jwt.NumericDate(now.Add(time.Minute)),
+				IssuedAt:       jwt.NumericDate(now),
+				JWTID:          "bWJq",
+			},
+			SessionID: "08a5019c-17e1-4977-8f42-65a12843ea02",
+			Events:    map[string]json.RawMessage{logout.BackChannelEvent: json.RawMessage("{}")},
+		}
+	}
+	testCases := []struct {
+		name     string
+		token    func() logout.Token
+		typ      string
+		verifier *logout.Verifier
+		sid      string
+		replay   bool
+		err      error
+	}{
+		{
+			name:     "valid",
+			token:    valid,
+			typ:      logout.Type,
+			verifier: &logout.Verifier{Issuer: issuer, ClientID: clientID, RequireType: true},
+			err:      nil,
+		},
+		{
+			name:     "missing type",
+			token:    valid,
+			verifier: &logout.Verifier{Issuer: issuer, ClientID: clientID, RequireType: true},
+			err:      jwt.ErrTypValidation,
+		},
+		{
+			name: "missing event",
+			token: func() logout.Token {
+				lt := valid()
+				lt.Events = map[string]json.RawMessage{"https://example.com/event": json.RawMessage("{}")}
+				return lt
+			},
+			verifier: &logout.Verifier{Issuer: issuer, ClientID: clientID},
+			err:      logout.ErrEventsValidation,
+		},
+		{
+			name: "event is not an object",
+			token: func() logout.Token {
+				lt := valid()
+				lt.Events[logout.BackChannelEvent] = json.RawMessage("true")
+				return lt
+			},
+			verifier: &logout.Verifier{Issuer: issuer, ClientID: clientID},
+			err:      logout.ErrEventsValidation,
+		},
+		{
+			name: "neither sub nor sid",
+			token: func() logout.Token {
+				lt := valid()
+				lt.Subject, lt.SessionID = "", ""
+				return lt
+			},
+			verifier: &logout.Verifier{Issuer: issuer, ClientID: clientID},
+			err:      logout.ErrSidValidation,
+		},
+		{
+			name: "nonce",
+			token: func() logout.Token {
+				lt := valid()
+				lt.Nonce = json.RawMessage(`"n-0S6_WzA2Mj"`)
+				return lt
+			},
+			verifier: &logout.Verifier{Issuer: issuer, ClientID: clientID},
+			err:      logout.ErrNonceNotAllowed,
+		},
+		{
+			name: "empty nonce",
+			token: func() logout.Token {
+				lt := valid()
+				lt.Nonce = json.RawMessage(`""`)
+				return lt
+			},
+			verifier: &logout.Verifier{Issuer: issuer, ClientID: clientID},
+			err:      logout.ErrNonceNotAllowed,
+		},
+		{
+			name: "null nonce",
+			token: func() logout.Token {
+				lt := valid()
+				lt.Nonce = json.RawMessage("null")
+				return lt
+			},
+			verifier: &logout.Verifier{Issuer: issuer, ClientID: clientID},
+			err:      logout.ErrNonceNotAllowed,
+		},
+		{
+			name: "missing jti",
+			token: func() logout.Token {
+				lt := valid()
+				lt.JWTID = ""
+				return lt
+			},
+			verifier: &logout.Verifier{Issuer: issuer, ClientID: clientID},
+			err:      jwt.ErrJtiValidation,
+		},
+		{
+			name: "expired",
+			token: func() logout.Token {
+				lt := valid()
+				lt.ExpirationTime = jwt.NumericDate(now.Add(-time.Minute))
+				return lt
+			},
+			verifier: &logout.Verifier{Issuer: issuer, ClientID: clientID},
+			err:      jwt.ErrExpValidation,
+		},
+		{
+			name:     "wrong audience",
+			token:    valid,
+			verifier: &logout.Verifier{Issuer: issuer, ClientID: "other"},
+			err:      jwt.ErrAudValidation,
+		},
+		{
+			name:     "wrong session",
+			token:    valid,
+			verifier: &logout.Verifier{Issuer: issuer, ClientID: clientID},
+			sid:      "other",
+			err:      logout.ErrSidValidation,
+		},
+		{
+			name:     "replay",
+			token:    valid,
+			verifier: &logout.Verifier{Issuer: issuer, ClientID: clientID, Cache: jwtutil.NewMemoryCache()},
+			replay:   true,
+			err:      jwtutil.ErrReplay,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var opts []jwt.SignOption
+			if tc.typ != "" {
+				opts = append(opts, jwt.Type(tc.typ))
+			}
+			token, err := jwt.Sign(tc.token(), hs256, opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var (
+				lt  logout.Token
+				vds []jwt.Validator
+			)
+			if tc.sid != "" {
+				vds = append(vds, logout.SessionIDValidator(&lt, tc.sid))
+			}
+			if tc.replay {
+				if _, err = tc.verifier.Verify(token, hs256, &lt, vds...); err != nil {
+					t.Fatal(err)
+				}
+			}
+			_, err = tc.verifier.Verify(token, hs256, &lt, vds...)
+			if want, got := tc.err, err; !internal.ErrorIs(got, want) {
+				t.Errorf("logout.Verifier.Verify err mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+		})
+	}
+}
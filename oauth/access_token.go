@@ -0,0 +1,137 @@
+package oauth
+
+import (
+	"crypto"
+	"crypto/x509"
+
+	"github.com/gbrlsnchs/jwt/v3"
+	"github.com/gbrlsnchs/jwt/v3/internal"
+)
+
+// AccessTokenType is the media type for JWT access tokens, as per the RFC 9068.
+// It should be used both with jwt.Type when signing and jwt.ValidateType when verifying.
+const AccessTokenType = "at+jwt"
+
+var (
+	// ErrAccessTokenMissingClaim is the error for when a JWT access token lacks a required claim.
+	ErrAccessTokenMissingClaim = internal.NewError("oauth: access token is missing a required claim")
+	// ErrClientIDValidation is the error for an invalid "client_id" claim.
+	ErrClientIDValidation = internal.NewError("oauth: client_id claim is invalid")
+	// ErrScopeValidation is the error for when a token lacks a required scope.
+	ErrScopeValidation = internal.NewError("oauth: scope claim is invalid")
+)
+
+// AccessToken is a JWT access token payload according to the RFC 9068.
+type AccessToken struct {
+	jwt.Payload
+	ClientID string    `json:"client_id,omitempty"`
+	Scope    Scope     `json:"scope,omitempty"`
+	AuthTime *jwt.Time `json:"auth_time,omitempty"`
+	ACR      string    `json:"acr,omitempty"`
+	AMR      []string  `json:"amr,omitempty"`
+
+	// Confirmation binds the token to a key or certificate, as per the RFC 7800.
+	Confirmation *jwt.Confirmation `json:"cnf,omitempty"`
+
+	// Delegation claims, as per the RFC 8693.
+	Actor  *Actor `json:"act,omitempty"`
+	MayAct *Actor `json:"may_act,omitempty"`
+
+	// Authorization claims, as per the RFC 7643.
+	Groups       []string `json:"groups,omitempty"`
+	Roles        []string `json:"roles,omitempty"`
+	Entitlements []string `json:"entitlements,omitempty"`
+}
+
+// HasScope checks whether the token has been granted scope.
+func (at *AccessToken) HasScope(scope string) bool {
+	return at.Scope.Has(scope)
+}
+
+// HasGroup checks whether the token's subject belongs to group.
+func (at *AccessToken) HasGroup(group string) bool {
+	return contains(at.Groups, group)
+}
+
+// HasRole checks whether the token's subject has been assigned role.
+func (at *AccessToken) HasRole(role string) bool {
+	return contains(at.Roles, role)
+}
+
+// HasEntitlement checks whether the token's subject has entitlement.
+func (at *AccessToken) HasEntitlement(entitlement string) bool {
+	return contains(at.Entitlements, entitlement)
+}
+
+// AccessTokenValidator validates all claims required by the RFC 9068 are present in at.
+// Since the Validator only receives the embedded Payload, at must be the same
+// struct whose Payload is passed to jwt.ValidatePayload.
+func AccessTokenValidator(at *AccessToken) jwt.Validator {
+	return func(pl *jwt.Payload) error {
+		var missing string
+		switch {
+		case pl.Issuer == "":
+			missing = "iss"
+		case pl.ExpirationTime == nil:
+			missing = "exp"
+		case len(pl.Audience) == 0:
+			missing = "aud"
+		case pl.Subject == "":
+			missing = "sub"
+		case at.ClientID == "":
+			missing = "client_id"
+		case pl.IssuedAt == nil:
+			missing = "iat"
+		case pl.JWTID == "":
+			missing = "jti"
+		default:
+			return nil
+		}
+		return internal.Errorf("oauth: %q: %w", missing, ErrAccessTokenMissingClaim)
+	}
+}
+
+// ClientIDValidator validates the "client_id" claim.
+func ClientIDValidator(at *AccessToken, clientID string) jwt.Validator {
+	return func(_ *jwt.Payload) error {
+		if at.ClientID != clientID {
+			return ErrClientIDValidation
+		}
+		return nil
+	}
+}
+
+// ScopeValidator validates the "scope" claim.
+// It checks whether all scopes are granted to the token.
+func ScopeValidator(at *AccessToken, scopes ...string) jwt.Validator {
+	return func(_ *jwt.Payload) error {
+		if !at.Scope.HasAll(scopes...) {
+			return ErrScopeValidation
+		}
+		return nil
+	}
+}
+
+// CertificateBoundValidator validates the token is bound to the TLS client certificate
+// it was presented with, as per the RFC 8705.
+func CertificateBoundValidator(at *AccessToken, cert *x509.Certificate) jwt.Validator {
+	return func(_ *jwt.Payload) error {
+		return at.Confirmation.VerifyCertificate(cert)
+	}
+}
+
+// KeyBoundValidator validates the token is bound to the public key its presenter proved to hold.
+func KeyBoundValidator(at *AccessToken, pub crypto.PublicKey) jwt.Validator {
+	return func(_ *jwt.Payload) error {
+		return at.Confirmation.VerifyKey(pub)
+	}
+}
+
+func contains(vs []string, v string) bool {
+	for _, vv := range vs {
+		if vv == v {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,156 @@
+package oauth_test
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"testing"
+	"time"
+
+	"github.com/gbrlsnchs/jwt/v3"
+	"github.com/gbrlsnchs/jwt/v3/internal"
+	"github.com/gbrlsnchs/jwt/v3/oauth"
+	"github.com/google/go-cmp/cmp"
+)
+
+var hs256 = jwt.NewHS256([]byte("oauth"))
+
+func TestAccessToken(t *testing.T) {
+	now := time.Now()
+	valid := oauth.AccessToken{
+		Payload: jwt.Payload{
+			Issuer:         "https://as.example.com",
+			Subject:        "someone",
+			Audience:       jwt.Audience{"https://rs.example.com"},
+			ExpirationTime: jwt.NumericDate(now.Add(time.Hour)),
+			IssuedAt:       jwt.NumericDate(now),
+			JWTID:          "foobar",
+		},
+		ClientID: "client",
+		Scope:    oauth.NewScope("read", "write"),
+		Roles:    []string{"admin"},
+	}
+	noClientID := valid
+	noClientID.ClientID = ""
+	noJTI := valid
+	noJTI.JWTID = ""
+	testCases := []struct {
+		at       oauth.AccessToken
+		signOpts []jwt.SignOption
+		vds      func(*oauth.AccessToken) []jwt.Validator
+		err      error
+	}{
+		{
+			at:       valid,
+			signOpts: []jwt.SignOption{jwt.Type(oauth.AccessTokenType)},
+			vds: func(at *oauth.AccessToken) []jwt.Validator {
+				return []jwt.Validator{
+					oauth.AccessTokenValidator(at),
+					oauth.ClientIDValidator(at, "client"),
+					oauth.ScopeValidator(at, "read"),
+					jwt.ExpirationTimeValidator(now),
+				}
+			},
+			err: nil,
+		},
+		{
+			at:       valid,
+			signOpts: nil,
+			vds: func(at *oauth.AccessToken) []jwt.Validator {
+				return []jwt.Validator{oauth.AccessTokenValidator(at)}
+			},
+			err: jwt.ErrTypValidation,
+		},
+		{
+			at:       noClientID,
+			signOpts: []jwt.SignOption{jwt.Type(oauth.AccessTokenType)},
+			vds: func(at *oauth.AccessToken) []jwt.Validator {
+				return []jwt.Validator{oauth.AccessTokenValidator(at)}
+			},
+			err: oauth.ErrAccessTokenMissingClaim,
+		},
+		{
+			at:       noJTI,
+			signOpts: []jwt.SignOption{jwt.Type(oauth.AccessTokenType)},
+			vds: func(at *oauth.AccessToken) []jwt.Validator {
+				return []jwt.Validator{oauth.AccessTokenValidator(at)}
+			},
+			err: oauth.ErrAccessTokenMissingClaim,
+		},
+		{
+			at:       valid,
+			signOpts: []jwt.SignOption{jwt.Type(oauth.AccessTokenType)},
+			vds: func(at *oauth.AccessToken) []jwt.Validator {
+				return []jwt.Validator{oauth.ClientIDValidator(at, "other")}
+			},
+			err: oauth.ErrClientIDValidation,
+		},
+		{
+			at:       valid,
+			signOpts: []jwt.SignOption{jwt.Type(oauth.AccessTokenType)},
+			vds: func(at *oauth.AccessToken) []jwt.Validator {
+				return []jwt.Validator{oauth.ScopeValidator(at, "read", "delete")}
+			},
+			err: oauth.ErrScopeValidation,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run("", func(t *testing.T) {
+			token, err := jwt.Sign(tc.at, hs256, tc.signOpts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var at oauth.AccessToken
+			_, err = jwt.Verify(token, hs256, &at,
+				jwt.ValidateType(oauth.AccessTokenType),
+				jwt.ValidatePayload(&at.Payload, tc.vds(&at)...),
+			)
+			if want, got := tc.err, err; !internal.ErrorIs(got, want) {
+				t.Fatalf("jwt.Verify err mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+			if err != nil {
+				return
+			}
+			if want, got := tc.at, at; !cmp.Equal(got, want) {
+				t.Errorf("oauth.AccessToken mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+			if !at.HasScope("write") || at.HasScope("delete") {
+				t.Errorf("oauth.AccessToken.HasScope mismatch")
+			}
+			if !at.HasRole("admin") || at.HasGroup("admin") {
+				t.Errorf("oauth.AccessToken.HasRole mismatch")
+			}
+		})
+	}
+}
+
+func TestKeyBoundAccessToken(t *testing.T) {
+	cnf, err := jwt.KeyConfirmation(&es256PrivateKey.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	testCases := []struct {
+		cnf *jwt.Confirmation
+		pub crypto.PublicKey
+		err error
+	}{
+		{cnf, &es256PrivateKey.PublicKey, nil},
+		{cnf, &otherKey.PublicKey, jwt.ErrCnfValidation},
+		{nil, &es256PrivateKey.PublicKey, jwt.ErrCnfValidation},
+	}
+	for _, tc := range testCases {
+		t.Run("", func(t *testing.T) {
+			token, err := jwt.Sign(oauth.AccessToken{Confirmation: tc.cnf}, hs256)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var at oauth.AccessToken
+			_, err = jwt.Verify(token, hs256, &at, jwt.ValidatePayload(&at.Payload, oauth.KeyBoundValidator(&at, tc.pub)))
+			if want, got := tc.err, err; !internal.ErrorIs(got, want) {
+				t.Errorf("oauth.KeyBoundValidator mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+		})
+	}
+}
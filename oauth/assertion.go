@@ -0,0 +1,178 @@
+package oauth
+
+import (
+	"time"
+
+	"github.com/gbrlsnchs/jwt/v3"
+	"github.com/gbrlsnchs/jwt/v3/internal"
+	"github.com/gbrlsnchs/jwt/v3/jwtutil"
+)
+
+const (
+	// ClientAssertionType is the "client_assertion_type" value for JWT client authentication,
+	// as per the RFC 7523.
+	ClientAssertionType = "urn:ietf:params:oauth:client-assertion-type:jwt-bearer"
+	// JWTBearerGrantType is the "grant_type" value for JWT authorization grants,
+	// as per the RFC 7523.
+	JWTBearerGrantType = "urn:ietf:params:oauth:grant-type:jwt-bearer"
+
+	defaultAssertionLifetime = 5 * time.Minute
+)
+
+// ErrLifetimeValidation is the error for when a token is valid for longer than allowed.
+var ErrLifetimeValidation = internal.NewError("oauth: token lifetime is too long")
+
+type assertion struct {
+	now      time.Time
+	lifetime time.Duration
+	jti      string
+	signOpts []jwt.SignOption
+}
+
+// AssertionOption is a functional option for building assertions and request objects.
+type AssertionOption func(*assertion)
+
+// AssertionID sets the "jti" claim of an assertion.
+// When not set, a random identifier is generated.
+func AssertionID(jti string) AssertionOption {
+	return func(a *assertion) {
+		a.jti = jti
+	}
+}
+
+// AssertionLifetime sets for how long an assertion is valid. Defaults to 5 minutes.
+func AssertionLifetime(d time.Duration) AssertionOption {
+	return func(a *assertion) {
+		a.lifetime = d
+	}
+}
+
+// AssertionTime sets the time used for the "iat" and "exp" claims. Defaults to time.Now.
+func AssertionTime(now time.Time) AssertionOption {
+	return func(a *assertion) {
+		a.now = now
+	}
+}
+
+// AssertionSignOptions sets options used when signing an assertion, like jwt.KeyID.
+func AssertionSignOptions(opts ...jwt.SignOption) AssertionOption {
+	return func(a *assertion) {
+		a.signOpts = opts
+	}
+}
+
+// NewClientAssertion signs an assertion used by clientID to authenticate at tokenEndpoint.
+//
+// Passing an asymmetric algorithm yields a "private_key_jwt" assertion, while an HMAC algorithm
+// keyed with the client secret yields a "client_secret_jwt" one.
+func NewClientAssertion(clientID, tokenEndpoint string, alg jwt.Algorithm, opts ...AssertionOption) ([]byte, error) {
+	return NewAuthorizationGrant(clientID, clientID, tokenEndpoint, alg, opts...)
+}
+
+// NewAuthorizationGrant signs an assertion issued by iss on behalf of sub in order to
+// request an access token at tokenEndpoint.
+func NewAuthorizationGrant(iss, sub, tokenEndpoint string, alg jwt.Algorithm, opts ...AssertionOption) ([]byte, error) {
+	a := assertion{lifetime: defaultAssertionLifetime}
+	for _, opt := range opts {
+		opt(&a)
+	}
+	if a.now.IsZero() {
+		a.now = time.Now()
+	}
+	if a.jti == "" {
+		var err error
+		if a.jti, err = internal.RandomID(16); err != nil {
+			return nil, err
+		}
+	}
+	pl := jwt.Payload{
+		Issuer:         iss,
+		Subject:        sub,
+		Audience:       jwt.Audience{tokenEndpoint},
+		ExpirationTime: jwt.NumericDate(a.now.Add(a.lifetime)),
+		IssuedAt:       jwt.NumericDate(a.now),
+		JWTID:          a.jti,
+	}
+	return jwt.Sign(pl, alg, a.signOpts...)
+}
+
+// AssertionVerifier verifies assertions received at a token endpoint.
+type AssertionVerifier struct {
+	// TokenEndpoint is the URL the "aud" claim must contain.
+	TokenEndpoint string
+	// MaxLifetime is the longest lifetime accepted for an assertion. Defaults to 5 minutes.
+	MaxLifetime time.Duration
+	// Cache records the "jti" of accepted assertions so they can only be used once.
+	// When nil, replays are not detected.
+	Cache jwtutil.ReplayCache
+	// Now returns the current time. Defaults to time.Now.
+	Now func() time.Time
+}
+
+// VerifyClientAssertion verifies an assertion used by clientID for authentication.
+// Both "iss" and "sub" claims must be equal to clientID.
+func (av *AssertionVerifier) VerifyClientAssertion(token []byte, alg jwt.Algorithm, clientID string) (*jwt.Payload, error) {
+	return av.VerifyAuthorizationGrant(token, alg,
+		jwt.IssuerValidator(clientID),
+		jwt.SubjectValidator(clientID),
+	)
+}
+
+// VerifyAuthorizationGrant verifies an authorization grant assertion.
+// Additional validators, like ones for "iss" and "sub", are run after the mandatory ones.
+func (av *AssertionVerifier) VerifyAuthorizationGrant(token []byte, alg jwt.Algorithm, vds ...jwt.Validator) (*jwt.Payload, error) {
+	now := time.Now()
+	if av.Now != nil {
+		now = av.Now()
+	}
+	maxLifetime := av.MaxLifetime
+	if maxLifetime == 0 {
+		maxLifetime = defaultAssertionLifetime
+	}
+	var pl jwt.Payload
+	vds = append([]jwt.Validator{
+		requiredValidator,
+		jwt.AudienceValidator(jwt.Audience{av.TokenEndpoint}),
+		jwt.ExpirationTimeValidator(now),
+		jwt.NotBeforeValidator(now),
+		jwt.IssuedAtValidator(now),
+		lifetimeValidator(now, maxLifetime),
+	}, vds...)
+	if av.Cache != nil {
+		vds = append(vds, jwtutil.ReplayValidator(av.Cache, now, maxLifetime))
+	}
+	if _, err := jwt.Verify(token, alg, &pl, jwt.ValidateHeader, jwt.ValidatePayload(&pl, vds...)); err != nil {
+		return nil, err
+	}
+	return &pl, nil
+}
+
+func requiredValidator(pl *jwt.Payload) error {
+	switch {
+	case pl.Issuer == "":
+		return jwt.ErrIssValidation
+	case pl.Subject == "":
+		return jwt.ErrSubValidation
+	case pl.JWTID == "":
+		return jwt.ErrJtiValidation
+	}
+	return nil
+}
+
+// lifetimeValidator checks a token does not live longer than d,
+// counting either from its "iat" claim or from now.
+func lifetimeValidator(now time.Time, d time.Duration) jwt.Validator {
+	return func(pl *jwt.Payload) error {
+		if pl.ExpirationTime == nil {
+			return jwt.ErrExpValidation
+		}
+		start := now
+		if pl.IssuedAt != nil {
+			start = pl.IssuedAt.Time
+		}
+		if pl.ExpirationTime.Sub(start) > d {
+			return ErrLifetimeValidation
+		}
+		return nil
+	}
+}
@@ -0,0 +1,136 @@
+package oauth_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"testing"
+	"time"
+
+	"github.com/gbrlsnchs/jwt/v3"
+	"github.com/gbrlsnchs/jwt/v3/internal"
+	"github.com/gbrlsnchs/jwt/v3/jwtutil"
+	"github.com/gbrlsnchs/jwt/v3/oauth"
+	"github.com/google/go-cmp/cmp"
+)
+
+const tokenEndpoint = "https://as.example.com/token"
+
+var es256PrivateKey, _ = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+
+func TestClientAssertion(t *testing.T) {
+	now := time.Now()
+	es256 := jwt.NewES256(jwt.ECDSAPrivateKey(es256PrivateKey))
+	testCases := []struct {
+		name     string
+		alg      jwt.Algorithm
+		clientID string
+		endpoint string
+		opts     []oauth.AssertionOption
+		verifier *oauth.AssertionVerifier
+		replay   bool
+		err      error
+	}{
+		{
+			name:     "private_key_jwt",
+			alg:      es256,
+			clientID: "client",
+			endpoint: tokenEndpoint,
+			verifier: &oauth.AssertionVerifier{TokenEndpoint: tokenEndpoint},
+			err:      nil,
+		},
+		{
+			name:     "client_secret_jwt",
+			alg:      hs256,
+			clientID: "client",
+			endpoint: tokenEndpoint,
+			verifier: &oauth.AssertionVerifier{TokenEndpoint: tokenEndpoint},
+			err:      nil,
+		},
+		{
+			name:     "wrong client",
+			alg:      hs256,
+			clientID: "other",
+			endpoint: tokenEndpoint,
+			verifier: &oauth.AssertionVerifier{TokenEndpoint: tokenEndpoint},
+			err:      jwt.ErrIssValidation,
+		},
+		{
+			name:     "wrong audience",
+			alg:      hs256,
+			clientID: "client",
+			endpoint: "https://as.example.com",
+			verifier: &oauth.AssertionVerifier{TokenEndpoint: tokenEndpoint},
+			err:      jwt.ErrAudValidation,
+		},
+		{
+			name:     "expired",
+			alg:      hs256,
+			clientID: "client",
+			endpoint: tokenEndpoint,
+			opts:     []oauth.AssertionOption{oauth.AssertionTime(now.Add(-time.Hour))},
+			verifier: &oauth.AssertionVerifier{TokenEndpoint: tokenEndpoint},
+			err:      jwt.ErrExpValidation,
+		},
+		{
+			name:     "lifetime too long",
+			alg:      hs256,
+			clientID: "client",
+			endpoint: tokenEndpoint,
+			opts:     []oauth.AssertionOption{oauth.AssertionLifetime(time.Hour)},
+			verifier: &oauth.AssertionVerifier{TokenEndpoint: tokenEndpoint},
+			err:      oauth.ErrLifetimeValidation,
+		},
+		{
+			name:     "replay",
+			alg:      hs256,
+			clientID: "client",
+			endpoint: tokenEndpoint,
+			opts:     []oauth.AssertionOption{oauth.AssertionID("foobar")},
+			verifier: &oauth.AssertionVerifier{
+				TokenEndpoint: tokenEndpoint,
+				Cache:         jwtutil.NewMemoryCache(),
+			},
+			replay: true,
+			err:    jwtutil.ErrReplay,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			token, err := oauth.NewClientAssertion(tc.clientID, tc.endpoint, tc.alg, tc.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tc.replay {
+				if _, err = tc.verifier.VerifyClientAssertion(token, tc.alg, "client"); err != nil {
+					t.Fatal(err)
+				}
+			}
+			pl, err := tc.verifier.VerifyClientAssertion(token, tc.alg, "client")
+			if want, got := tc.err, err; !internal.ErrorIs(got, want) {
+				t.Fatalf("oauth.AssertionVerifier.VerifyClientAssertion err mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+			if err != nil {
+				return
+			}
+			if pl.JWTID == "" {
+				t.Errorf(`"jti" claim is empty`)
+			}
+		})
+	}
+}
+
+func TestAuthorizationGrant(t *testing.T) {
+	token, err := oauth.NewAuthorizationGrant("https://idp.example.com", "someone", tokenEndpoint, hs256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	av := oauth.AssertionVerifier{TokenEndpoint: tokenEndpoint}
+	pl, err := av.VerifyAuthorizationGrant(token, hs256, jwt.IssuerValidator("https://idp.example.com"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, got := "someone", pl.Subject; got != want {
+		t.Errorf("oauth.AssertionVerifier.VerifyAuthorizationGrant mismatch (-want +got):\n%s", cmp.Diff(want, got))
+	}
+}
@@ -0,0 +1,2 @@
+// Package oauth implements JWT profiles and claims used by OAuth 2.0.
+package oauth
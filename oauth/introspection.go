@@ -0,0 +1,62 @@
+package oauth
+
+import (
+	"time"
+
+	"github.com/gbrlsnchs/jwt/v3"
+)
+
+// IntrospectionType is the media type for JWT introspection responses, as per the RFC 9701.
+const IntrospectionType = "token-introspection+jwt"
+
+// Introspection is the state of a token, as per the RFC 7662.
+// Its embedded Payload holds the claims of the introspected token, not of the response.
+type Introspection struct {
+	jwt.Payload
+	Active       bool              `json:"active"`
+	Scope        Scope             `json:"scope,omitempty"`
+	ClientID     string            `json:"client_id,omitempty"`
+	Username     string            `json:"username,omitempty"`
+	TokenType    string            `json:"token_type,omitempty"`
+	Confirmation *jwt.Confirmation `json:"cnf,omitempty"`
+}
+
+// IntrospectionResponse is the payload of a JWT introspection response.
+type IntrospectionResponse struct {
+	jwt.Payload
+	TokenIntrospection Introspection `json:"token_introspection"`
+}
+
+// NewIntrospectionResponse signs the introspection result ti on behalf of issuer
+// for the resource server identified by aud.
+func NewIntrospectionResponse(ti Introspection, issuer, aud string, alg jwt.Algorithm, opts ...jwt.SignOption) ([]byte, error) {
+	resp := IntrospectionResponse{
+		Payload: jwt.Payload{
+			Issuer:   issuer,
+			Audience: jwt.Audience{aud},
+			IssuedAt: jwt.NumericDate(time.Now()),
+		},
+		TokenIntrospection: ti,
+	}
+	return jwt.Sign(resp, alg, append(opts[:len(opts):len(opts)], jwt.Type(IntrospectionType))...)
+}
+
+// VerifyIntrospectionResponse verifies a JWT introspection response issued by issuer
+// for the resource server identified by aud. Validators in vds are run against the response's claims.
+func VerifyIntrospectionResponse(token []byte, alg jwt.Algorithm, issuer, aud string, vds ...jwt.Validator) (*IntrospectionResponse, error) {
+	var resp IntrospectionResponse
+	vds = append([]jwt.Validator{
+		jwt.IssuerValidator(issuer),
+		jwt.AudienceValidator(jwt.Audience{aud}),
+		jwt.IssuedAtValidator(time.Now()),
+	}, vds...)
+	_, err := jwt.Verify(token, alg, &resp,
+		jwt.ValidateHeader,
+		jwt.ValidateType(IntrospectionType),
+		jwt.ValidatePayload(&resp.Payload, vds...),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
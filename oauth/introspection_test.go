@@ -0,0 +1,96 @@
+package oauth_test
+
+import (
+	"testing"
+
+	"github.com/gbrlsnchs/jwt/v3"
+	"github.com/gbrlsnchs/jwt/v3/internal"
+	"github.com/gbrlsnchs/jwt/v3/oauth"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestIntrospectionResponse(t *testing.T) {
+	ti := oauth.Introspection{
+		Payload: jwt.Payload{
+			Subject: "Z5O3upPC88QrAjx00dis",
+			Issuer:  issuer,
+		},
+		Active:   true,
+		Scope:    oauth.NewScope("read", "write"),
+		ClientID: "s6BhdRkqt3",
+	}
+	testCases := []struct {
+		name   string
+		token  func() ([]byte, error)
+		issuer string
+		aud    string
+		err    error
+	}{
+		{
+			name:   "valid",
+			token:  func() ([]byte, error) { return oauth.NewIntrospectionResponse(ti, issuer, "rs", hs256) },
+			issuer: issuer,
+			aud:    "rs",
+			err:    nil,
+		},
+		{
+			name: "conflicting type",
+			token: func() ([]byte, error) {
+				return oauth.NewIntrospectionResponse(ti, issuer, "rs", hs256, jwt.Type("JWT"))
+			},
+			issuer: issuer,
+			aud:    "rs",
+			err:    nil,
+		},
+		{
+			name:   "wrong issuer",
+			token:  func() ([]byte, error) { return oauth.NewIntrospectionResponse(ti, issuer, "rs", hs256) },
+			issuer: "https://other.example.com",
+			aud:    "rs",
+			err:    jwt.ErrIssValidation,
+		},
+		{
+			name:   "wrong audience",
+			token:  func() ([]byte, error) { return oauth.NewIntrospectionResponse(ti, issuer, "other", hs256) },
+			issuer: issuer,
+			aud:    "rs",
+			err:    jwt.ErrAudValidation,
+		},
+		{
+			name: "wrong type",
+			token: func() ([]byte, error) {
+				return jwt.Sign(oauth.IntrospectionResponse{
+					Payload:            jwt.Payload{Issuer: issuer, Audience: jwt.Audience{"rs"}},
+					TokenIntrospection: ti,
+				}, hs256)
+			},
+			issuer: issuer,
+			aud:    "rs",
+			err:    jwt.ErrTypValidation,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			token, err := tc.token()
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp, err := oauth.VerifyIntrospectionResponse(token, hs256, tc.issuer, tc.aud)
+			if want, got := tc.err, err; !internal.ErrorIs(got, want) {
+				t.Fatalf("oauth.VerifyIntrospectionResponse err mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+			if err != nil {
+				return
+			}
+			if want, got := ti.Subject, resp.TokenIntrospection.Subject; got != want {
+				t.Errorf("sub mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+			if !resp.TokenIntrospection.Active {
+				t.Errorf("token is not active")
+			}
+			if !resp.TokenIntrospection.Scope.HasAll("read", "write") {
+				t.Errorf("scope mismatch: %v", resp.TokenIntrospection.Scope)
+			}
+		})
+	}
+}
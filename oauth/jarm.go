@@ -0,0 +1,72 @@
+package oauth
+
+import (
+	"time"
+
+	"github.com/gbrlsnchs/jwt/v3"
+	"github.com/gbrlsnchs/jwt/v3/internal"
+)
+
+const defaultAuthorizationResponseLifetime = 10 * time.Minute
+
+var (
+	// ErrStateValidation is the error for an invalid "state" claim.
+	ErrStateValidation = internal.NewError("oauth: state claim is invalid")
+	// ErrAuthorizationResponse is the error for an authorization response carrying an error.
+	ErrAuthorizationResponse = internal.NewError("oauth: authorization server returned an error")
+)
+
+// AuthorizationResponse is a JWT-secured authorization response (JARM).
+type AuthorizationResponse struct {
+	jwt.Payload
+	Code             string `json:"code,omitempty"`
+	State            string `json:"state,omitempty"`
+	Error            string `json:"error,omitempty"`
+	ErrorDescription string `json:"error_description,omitempty"`
+	ErrorURI         string `json:"error_uri,omitempty"`
+}
+
+// NewAuthorizationResponse signs resp on behalf of issuer for the client identified by clientID.
+// It is valid for 10 minutes unless AssertionLifetime is used.
+func NewAuthorizationResponse(resp AuthorizationResponse, issuer, clientID string, alg jwt.Algorithm, opts ...AssertionOption) ([]byte, error) {
+	a := assertion{lifetime: defaultAuthorizationResponseLifetime}
+	for _, opt := range opts {
+		opt(&a)
+	}
+	if a.now.IsZero() {
+		a.now = time.Now()
+	}
+	resp.Issuer = issuer
+	resp.Audience = jwt.Audience{clientID}
+	resp.ExpirationTime = jwt.NumericDate(a.now.Add(a.lifetime))
+	resp.IssuedAt = jwt.NumericDate(a.now)
+	return jwt.Sign(resp, alg, a.signOpts...)
+}
+
+// VerifyAuthorizationResponse verifies a JWT-secured authorization response issued by issuer
+// for the client identified by clientID and checks it carries the state sent in the request.
+// When the response carries an error, it is returned alongside an error wrapping ErrAuthorizationResponse.
+func VerifyAuthorizationResponse(token []byte, alg jwt.Algorithm, issuer, clientID, state string, vds ...jwt.Validator) (*AuthorizationResponse, error) {
+	var (
+		resp AuthorizationResponse
+		now  = time.Now()
+	)
+	vds = append([]jwt.Validator{
+		jwt.IssuerValidator(issuer),
+		jwt.AudienceValidator(jwt.Audience{clientID}),
+		jwt.ExpirationTimeValidator(now),
+		func(_ *jwt.Payload) error {
+			if resp.State != state {
+				return ErrStateValidation
+			}
+			return nil
+		},
+	}, vds...)
+	if _, err := jwt.Verify(token, alg, &resp, jwt.ValidateHeader, jwt.ValidatePayload(&resp.Payload, vds...)); err != nil {
+		return nil, err
+	}
+	if resp.Error != "" {
+		return &resp, internal.Errorf("oauth: %q: %w", resp.Error, ErrAuthorizationResponse)
+	}
+	return &resp, nil
+}
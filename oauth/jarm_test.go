@@ -0,0 +1,77 @@
+package oauth_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gbrlsnchs/jwt/v3"
+	"github.com/gbrlsnchs/jwt/v3/internal"
+	"github.com/gbrlsnchs/jwt/v3/oauth"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestAuthorizationResponse(t *testing.T) {
+	testCases := []struct {
+		name     string
+		resp     oauth.AuthorizationResponse
+		clientID string
+		state    string
+		opts     []oauth.AssertionOption
+		err      error
+	}{
+		{
+			name:     "code",
+			resp:     oauth.AuthorizationResponse{Code: "PyyFaux2o7Q0YfXBU32jhw.5FXSQpvr8akv9CeRDSd0QA", State: "S8NJ7uqk5fY4EjNvP_G_FtyJu6pUsvH9jsYni9dMAJw"},
+			clientID: "s6BhdRkqt3",
+			state:    "S8NJ7uqk5fY4EjNvP_G_FtyJu6pUsvH9jsYni9dMAJw",
+			err:      nil,
+		},
+		{
+			name:     "wrong state",
+			resp:     oauth.AuthorizationResponse{Code: "code", State: "state"},
+			clientID: "s6BhdRkqt3",
+			state:    "other",
+			err:      oauth.ErrStateValidation,
+		},
+		{
+			name:     "wrong client",
+			resp:     oauth.AuthorizationResponse{Code: "code", State: "state"},
+			clientID: "other",
+			state:    "state",
+			err:      jwt.ErrAudValidation,
+		},
+		{
+			name:     "expired",
+			resp:     oauth.AuthorizationResponse{Code: "code", State: "state"},
+			clientID: "s6BhdRkqt3",
+			state:    "state",
+			opts:     []oauth.AssertionOption{oauth.AssertionTime(time.Now().Add(-time.Hour))},
+			err:      jwt.ErrExpValidation,
+		},
+		{
+			name:     "error",
+			resp:     oauth.AuthorizationResponse{Error: "access_denied", State: "state"},
+			clientID: "s6BhdRkqt3",
+			state:    "state",
+			err:      oauth.ErrAuthorizationResponse,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			token, err := oauth.NewAuthorizationResponse(tc.resp, issuer, "s6BhdRkqt3", hs256, tc.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp, err := oauth.VerifyAuthorizationResponse(token, hs256, issuer, tc.clientID, tc.state)
+			if want, got := tc.err, err; !internal.ErrorIs(got, want) {
+				t.Fatalf("oauth.VerifyAuthorizationResponse err mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+			if err != nil {
+				return
+			}
+			if want, got := tc.resp.Code, resp.Code; got != want {
+				t.Errorf("code mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+		})
+	}
+}
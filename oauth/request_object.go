@@ -0,0 +1,194 @@
+package oauth
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/gbrlsnchs/jwt/v3"
+	"github.com/gbrlsnchs/jwt/v3/internal"
+	"github.com/gbrlsnchs/jwt/v3/jwtutil"
+)
+
+// RequestObjectType is the media type for request objects, as per the RFC 9101.
+const RequestObjectType = "oauth-authz-req+jwt"
+
+const defaultRequestObjectLifetime = time.Hour
+
+var (
+	// ErrRequestObject is the error for a missing or ambiguous request object.
+	ErrRequestObject = internal.NewError(`oauth: either "request" or "request_uri" must be set`)
+	// ErrRequestURINotSupported is the error for a "request_uri" parameter
+	// when no means of fetching it has been configured.
+	ErrRequestURINotSupported = internal.NewError("oauth: request_uri parameter is not supported")
+)
+
+// RequestObject is a JWT-secured authorization request.
+type RequestObject struct {
+	jwt.Payload
+	ClientID            string `json:"client_id"`
+	ResponseType        string `json:"response_type,omitempty"`
+	RedirectURI         string `json:"redirect_uri,omitempty"`
+	Scope               Scope  `json:"scope,omitempty"`
+	State               string `json:"state,omitempty"`
+	Nonce               string `json:"nonce,omitempty"`
+	CodeChallenge       string `json:"code_challenge,omitempty"`
+	CodeChallengeMethod string `json:"code_challenge_method,omitempty"`
+
+	// Params holds every authorization parameter in the request object, including the ones above.
+	Params map[string]interface{} `json:"-"`
+}
+
+// UnmarshalJSON implements an unmarshaling function that also fills Params.
+func (ro *RequestObject) UnmarshalJSON(b []byte) error {
+	type requestObject RequestObject // prevents recursion
+	if err := json.Unmarshal(b, (*requestObject)(ro)); err != nil {
+		return err
+	}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	return dec.Decode(&ro.Params)
+}
+
+// NewRequestObject signs params as a request object issued by clientID for the authorization
+// server identified by issuer. Parameters holding JSON objects, like "claims" and
+// "authorization_details", are embedded as such.
+func NewRequestObject(params url.Values, clientID, issuer string, alg jwt.Algorithm, opts ...AssertionOption) ([]byte, error) {
+	a := assertion{lifetime: defaultAssertionLifetime}
+	for _, opt := range opts {
+		opt(&a)
+	}
+	if a.now.IsZero() {
+		a.now = time.Now()
+	}
+	if a.jti == "" {
+		var err error
+		if a.jti, err = internal.RandomID(16); err != nil {
+			return nil, err
+		}
+	}
+	pl := make(map[string]interface{}, len(params)+6)
+	for k, vs := range params {
+		if len(vs) == 0 || k == "request" || k == "request_uri" {
+			continue
+		}
+		pl[k] = paramValue(k, vs)
+	}
+	pl["iss"] = clientID
+	pl["client_id"] = clientID
+	pl["aud"] = issuer
+	pl["iat"] = jwt.NumericDate(a.now)
+	pl["nbf"] = jwt.NumericDate(a.now)
+	pl["exp"] = jwt.NumericDate(a.now.Add(a.lifetime))
+	pl["jti"] = a.jti
+	return jwt.Sign(pl, alg, append(a.signOpts[:len(a.signOpts):len(a.signOpts)], jwt.Type(RequestObjectType))...)
+}
+
+func paramValue(k string, vs []string) interface{} {
+	if len(vs) > 1 {
+		return vs
+	}
+	v := vs[0]
+	switch k {
+	case "claims", "authorization_details":
+		if json.Valid([]byte(v)) {
+			return json.RawMessage(v)
+		}
+	case "max_age":
+		if n, err := strconv.ParseInt(v, 10, 64); err == nil {
+			return n
+		}
+	}
+	return v
+}
+
+// RequestObjectVerifier verifies request objects received at the authorization endpoint.
+type RequestObjectVerifier struct {
+	// Issuer is the authorization server's issuer identifier, which the "aud" claim must contain.
+	Issuer string
+	// MaxLifetime is the longest lifetime accepted for a request object. Defaults to 1 hour.
+	MaxLifetime time.Duration
+	// RequireType rejects request objects whose "typ" header is not RequestObjectType.
+	RequireType bool
+	// Fetch retrieves the request object referenced by "request_uri".
+	// When nil, the "request_uri" parameter is rejected.
+	Fetch func(uri string) ([]byte, error)
+	// Decrypt decrypts encrypted request objects, returning the nested signed request object.
+	// When nil, request objects must be signed JWTs.
+	Decrypt func(token []byte) ([]byte, error)
+	// Cache records the "jti" of accepted request objects so they can only be used once.
+	// When nil, replays are not detected.
+	Cache jwtutil.ReplayCache
+	// Now returns the current time. Defaults to time.Now.
+	Now func() time.Time
+}
+
+// Verify extracts the request object from the query of an authorization request and verifies it
+// with alg, which holds the keys registered by the client identified by the "client_id" parameter.
+// Only parameters in the returned request object should be used, as per the RFC 9101.
+func (v *RequestObjectVerifier) Verify(query url.Values, alg jwt.Algorithm) (*RequestObject, error) {
+	token, err := v.requestObject(query)
+	if err != nil {
+		return nil, err
+	}
+	if v.Decrypt != nil {
+		if token, err = v.Decrypt(token); err != nil {
+			return nil, err
+		}
+	}
+	now := time.Now()
+	if v.Now != nil {
+		now = v.Now()
+	}
+	maxLifetime := v.MaxLifetime
+	if maxLifetime == 0 {
+		maxLifetime = defaultRequestObjectLifetime
+	}
+	var (
+		ro       RequestObject
+		clientID = query.Get("client_id")
+	)
+	vds := []jwt.Validator{
+		func(pl *jwt.Payload) error {
+			if ro.ClientID == "" || ro.ClientID != clientID {
+				return ErrClientIDValidation
+			}
+			if pl.Issuer != "" && pl.Issuer != clientID {
+				return jwt.ErrIssValidation
+			}
+			return nil
+		},
+		jwt.AudienceValidator(jwt.Audience{v.Issuer}),
+		jwt.ExpirationTimeValidator(now),
+		jwt.NotBeforeValidator(now),
+		jwt.IssuedAtValidator(now),
+		lifetimeValidator(now, maxLifetime),
+	}
+	if v.Cache != nil {
+		vds = append(vds, jwtutil.ReplayValidator(v.Cache, now, maxLifetime))
+	}
+	opts := []jwt.VerifyOption{jwt.ValidateHeader, jwt.ValidatePayload(&ro.Payload, vds...)}
+	if v.RequireType {
+		opts = append(opts, jwt.ValidateType(RequestObjectType))
+	}
+	if _, err = jwt.Verify(token, alg, &ro, opts...); err != nil {
+		return nil, err
+	}
+	return &ro, nil
+}
+
+func (v *RequestObjectVerifier) requestObject(query url.Values) ([]byte, error) {
+	req, reqURI := query.Get("request"), query.Get("request_uri")
+	switch {
+	case req != "" && reqURI == "":
+		return []byte(req), nil
+	case req == "" && reqURI != "":
+		if v.Fetch == nil {
+			return nil, ErrRequestURINotSupported
+		}
+		return v.Fetch(reqURI)
+	}
+	return nil, ErrRequestObject
+}
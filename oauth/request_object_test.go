@@ -0,0 +1,116 @@
+package oauth_test
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/gbrlsnchs/jwt/v3"
+	"github.com/gbrlsnchs/jwt/v3/internal"
+	"github.com/gbrlsnchs/jwt/v3/oauth"
+	"github.com/google/go-cmp/cmp"
+)
+
+const issuer = "https://as.example.com"
+
+func TestRequestObject(t *testing.T) {
+	params := url.Values{
+		"response_type": {"code"},
+		"redirect_uri":  {"https://client.example.com/cb"},
+		"scope":         {"openid profile"},
+		"state":         {"af0ifjsldkj"},
+		"max_age":       {"86400"},
+		"claims":        {`{"id_token":{"acr":{"essential":true}}}`},
+	}
+	sign := func(clientID, aud string, opts ...oauth.AssertionOption) string {
+		token, err := oauth.NewRequestObject(params, clientID, aud, hs256, opts...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(token)
+	}
+	testCases := []struct {
+		name     string
+		query    url.Values
+		verifier oauth.RequestObjectVerifier
+		err      error
+	}{
+		{
+			name:     "request",
+			query:    url.Values{"client_id": {"client"}, "request": {sign("client", issuer)}},
+			verifier: oauth.RequestObjectVerifier{Issuer: issuer, RequireType: true},
+			err:      nil,
+		},
+		{
+			name:     "conflicting type",
+			query:    url.Values{"client_id": {"client"}, "request": {sign("client", issuer, oauth.AssertionSignOptions(jwt.Type("JWT")))}},
+			verifier: oauth.RequestObjectVerifier{Issuer: issuer, RequireType: true},
+			err:      nil,
+		},
+		{
+			name:  "request_uri",
+			query: url.Values{"client_id": {"client"}, "request_uri": {"urn:example:request"}},
+			verifier: oauth.RequestObjectVerifier{
+				Issuer: issuer,
+				Fetch: func(uri string) ([]byte, error) {
+					if uri != "urn:example:request" {
+						return nil, errors.New("not found")
+					}
+					return []byte(sign("client", issuer)), nil
+				},
+			},
+			err: nil,
+		},
+		{
+			name:     "request_uri not supported",
+			query:    url.Values{"client_id": {"client"}, "request_uri": {"urn:example:request"}},
+			verifier: oauth.RequestObjectVerifier{Issuer: issuer},
+			err:      oauth.ErrRequestURINotSupported,
+		},
+		{
+			name:     "both request and request_uri",
+			query:    url.Values{"client_id": {"client"}, "request": {sign("client", issuer)}, "request_uri": {"urn:example:request"}},
+			verifier: oauth.RequestObjectVerifier{Issuer: issuer},
+			err:      oauth.ErrRequestObject,
+		},
+		{
+			name:     "client_id mismatch",
+			query:    url.Values{"client_id": {"client"}, "request": {sign("other", issuer)}},
+			verifier: oauth.RequestObjectVerifier{Issuer: issuer},
+			err:      oauth.ErrClientIDValidation,
+		},
+		{
+			name:     "wrong audience",
+			query:    url.Values{"client_id": {"client"}, "request": {sign("client", "https://other.example.com")}},
+			verifier: oauth.RequestObjectVerifier{Issuer: issuer},
+			err:      jwt.ErrAudValidation,
+		},
+		{
+			name:     "lifetime too long",
+			query:    url.Values{"client_id": {"client"}, "request": {sign("client", issuer, oauth.AssertionLifetime(2*time.Hour))}},
+			verifier: oauth.RequestObjectVerifier{Issuer: issuer},
+			err:      oauth.ErrLifetimeValidation,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ro, err := tc.verifier.Verify(tc.query, hs256)
+			if want, got := tc.err, err; !internal.ErrorIs(got, want) {
+				t.Fatalf("oauth.RequestObjectVerifier.Verify err mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+			if err != nil {
+				return
+			}
+			if want, got := "https://client.example.com/cb", ro.RedirectURI; got != want {
+				t.Errorf("redirect_uri mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+			if !ro.Scope.HasAll("openid", "profile") {
+				t.Errorf("scope mismatch: %v", ro.Scope)
+			}
+			if _, ok := ro.Params["claims"].(map[string]interface{}); !ok {
+				t.Errorf("claims parameter is not a JSON object: %#v", ro.Params["claims"])
+			}
+		})
+	}
+}
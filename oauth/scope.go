@@ -0,0 +1,76 @@
+package oauth
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+)
+
+// Scope is a set of scope values. It is encoded as a
+// space-delimited string, as per the RFC 6749.
+type Scope map[string]struct{}
+
+// ParseScope parses a space-delimited list of scope values.
+func ParseScope(s string) Scope {
+	fields := strings.Fields(s)
+	sc := make(Scope, len(fields))
+	for _, f := range fields {
+		sc[f] = struct{}{}
+	}
+	return sc
+}
+
+// NewScope creates a set of scope values.
+func NewScope(values ...string) Scope {
+	sc := make(Scope, len(values))
+	for _, v := range values {
+		sc[v] = struct{}{}
+	}
+	return sc
+}
+
+// Has checks whether v is contained in the set.
+func (sc Scope) Has(v string) bool {
+	_, ok := sc[v]
+	return ok
+}
+
+// HasAll checks whether every value in vs is contained in the set.
+func (sc Scope) HasAll(vs ...string) bool {
+	for _, v := range vs {
+		if !sc.Has(v) {
+			return false
+		}
+	}
+	return true
+}
+
+// Values returns the scope values in lexical order.
+func (sc Scope) Values() []string {
+	vs := make([]string, 0, len(sc))
+	for v := range sc {
+		vs = append(vs, v)
+	}
+	sort.Strings(vs)
+	return vs
+}
+
+// String returns the space-delimited representation of the set.
+func (sc Scope) String() string {
+	return strings.Join(sc.Values(), " ")
+}
+
+// MarshalJSON implements a marshaling function for "scope" claim.
+func (sc Scope) MarshalJSON() ([]byte, error) {
+	return json.Marshal(sc.String())
+}
+
+// UnmarshalJSON implements an unmarshaling function for "scope" claim.
+func (sc *Scope) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	*sc = ParseScope(s)
+	return nil
+}
@@ -0,0 +1,55 @@
+package oauth_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/gbrlsnchs/jwt/v3/oauth"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestScopeMarshal(t *testing.T) {
+	testCases := []struct {
+		scope    oauth.Scope
+		expected string
+	}{
+		{oauth.NewScope("read"), `"read"`},
+		{oauth.NewScope("write", "read"), `"read write"`},
+		{oauth.NewScope(), `""`},
+		{nil, `""`},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.expected, func(t *testing.T) {
+			b, err := json.Marshal(tc.scope)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want, got := tc.expected, string(b); got != want {
+				t.Errorf("oauth.Scope.Marshal mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+		})
+	}
+}
+
+func TestScopeUnmarshal(t *testing.T) {
+	testCases := []struct {
+		jstr     string
+		expected oauth.Scope
+	}{
+		{`"read"`, oauth.NewScope("read")},
+		{`"read write"`, oauth.NewScope("read", "write")},
+		{`"  read   write read "`, oauth.NewScope("read", "write")},
+		{`""`, oauth.NewScope()},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.jstr, func(t *testing.T) {
+			var sc oauth.Scope
+			if err := json.Unmarshal([]byte(tc.jstr), &sc); err != nil {
+				t.Fatal(err)
+			}
+			if want, got := tc.expected, sc; !cmp.Equal(got, want) {
+				t.Errorf("oauth.Scope.Unmarshal mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+		})
+	}
+}
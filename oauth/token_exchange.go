@@ -0,0 +1,102 @@
+package oauth
+
+import (
+	"github.com/gbrlsnchs/jwt/v3"
+	"github.com/gbrlsnchs/jwt/v3/internal"
+)
+
+// TokenExchangeGrantType is the grant type for token exchange, as per the RFC 8693.
+const TokenExchangeGrantType = "urn:ietf:params:oauth:grant-type:token-exchange"
+
+var (
+	// ErrActValidation is the error for an invalid "act" claim.
+	ErrActValidation = internal.NewError("oauth: act claim is invalid")
+	// ErrMayActValidation is the error for an actor not authorized by the "may_act" claim.
+	ErrMayActValidation = internal.NewError("oauth: may_act claim is invalid")
+)
+
+// Actor identifies a party acting on behalf of a token's subject.
+// It is the value of both the "act" and "may_act" claims, as per the RFC 8693.
+type Actor struct {
+	Subject  string `json:"sub,omitempty"`
+	Issuer   string `json:"iss,omitempty"`
+	ClientID string `json:"client_id,omitempty"`
+	// Actor is the prior actor in the delegation chain.
+	// It is never set for "may_act" claims.
+	Actor *Actor `json:"act,omitempty"`
+}
+
+// Chain returns the delegation chain starting at a,
+// that is, the current actor followed by all prior actors.
+func (a *Actor) Chain() []*Actor {
+	var chain []*Actor
+	for ; a != nil; a = a.Actor {
+		chain = append(chain, a)
+	}
+	return chain
+}
+
+// Is checks whether b is the party identified by a, regardless of their prior actors.
+// Only the members set in a are compared, so that a "may_act" claim naming just a subject
+// authorizes that subject whatever its issuer or client. An a with no members set identifies no party.
+func (a *Actor) Is(b *Actor) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	if a.Subject == "" && a.Issuer == "" && a.ClientID == "" {
+		return false
+	}
+	return (a.Subject == "" || a.Subject == b.Subject) &&
+		(a.Issuer == "" || a.Issuer == b.Issuer) &&
+		(a.ClientID == "" || a.ClientID == b.ClientID)
+}
+
+// ActorValidator validates the "act" claim.
+// It checks whether every actor in the delegation chain of at satisfies allow.
+func ActorValidator(at *AccessToken, allow func(*Actor) bool) jwt.Validator {
+	return func(_ *jwt.Payload) error {
+		for _, act := range at.Actor.Chain() {
+			if !allow(act) {
+				return ErrActValidation
+			}
+		}
+		return nil
+	}
+}
+
+// ActorChainValidator validates the "act" claim.
+// It checks whether the delegation chain of at has at most depth actors.
+func ActorChainValidator(at *AccessToken, depth int) jwt.Validator {
+	return func(_ *jwt.Payload) error {
+		if len(at.Actor.Chain()) > depth {
+			return ErrActValidation
+		}
+		return nil
+	}
+}
+
+// MayActValidator validates the "may_act" claim.
+// It checks whether actor is authorized to act on behalf of the subject of at.
+func MayActValidator(at *AccessToken, actor *Actor) jwt.Validator {
+	return func(_ *jwt.Payload) error {
+		if !at.MayAct.Is(actor) {
+			return ErrMayActValidation
+		}
+		return nil
+	}
+}
+
+// Exchange signs a token for the subject of st, the subject token of a token exchange, with actor
+// acting on its behalf. Claims are taken from at, except for "sub", which is preserved from st,
+// "act", whose value is actor followed by the delegation chain of st, and "may_act", which is cleared.
+// When st has a "may_act" claim, actor must be authorized by it.
+func Exchange(st *AccessToken, actor Actor, at AccessToken, alg jwt.Algorithm, opts ...jwt.SignOption) ([]byte, error) {
+	if st.MayAct != nil && !st.MayAct.Is(&actor) {
+		return nil, ErrMayActValidation
+	}
+	actor.Actor = st.Actor
+	at.Subject = st.Subject
+	at.Actor = &actor
+	at.MayAct = nil
+	return jwt.Sign(at, alg, append(opts[:len(opts):len(opts)], jwt.Type(AccessTokenType))...)
+}
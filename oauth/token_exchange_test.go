@@ -0,0 +1,174 @@
+package oauth_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gbrlsnchs/jwt/v3"
+	"github.com/gbrlsnchs/jwt/v3/internal"
+	"github.com/gbrlsnchs/jwt/v3/oauth"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestExchange(t *testing.T) {
+	var (
+		now     = time.Now()
+		service = oauth.Actor{Subject: "https://service16.example.com"}
+		st      = &oauth.AccessToken{
+			Payload: jwt.Payload{Subject: "user@example.net"},
+			Actor:   &oauth.Actor{Subject: "https://service77.example.com"},
+		}
+	)
+	testCases := []struct {
+		name    string
+		st      *oauth.AccessToken
+		actor   oauth.Actor
+		opts    []jwt.SignOption
+		vds     func(*oauth.AccessToken) []jwt.Validator
+		wantSub []string
+		err     error
+	}{
+		{
+			name:    "first actor",
+			st:      &oauth.AccessToken{Payload: jwt.Payload{Subject: "user@example.net"}},
+			actor:   service,
+			wantSub: []string{"https://service16.example.com"},
+			err:     nil,
+		},
+		{
+			name:    "appended actor",
+			st:      st,
+			actor:   service,
+			wantSub: []string{"https://service16.example.com", "https://service77.example.com"},
+			err:     nil,
+		},
+		{
+			name: "may_act",
+			st: &oauth.AccessToken{
+				Payload: jwt.Payload{Subject: "user@example.net"},
+				MayAct:  &oauth.Actor{Subject: "https://service16.example.com"},
+			},
+			actor:   service,
+			wantSub: []string{"https://service16.example.com"},
+			err:     nil,
+		},
+		{
+			name: "may_act with subject only",
+			st: &oauth.AccessToken{
+				Payload: jwt.Payload{Subject: "user@example.net"},
+				MayAct:  &oauth.Actor{Subject: "https://service16.example.com"},
+			},
+			actor:   oauth.Actor{Subject: "https://service16.example.com", ClientID: "s6BhdRkqt3"},
+			wantSub: []string{"https://service16.example.com"},
+			err:     nil,
+		},
+		{
+			name:    "conflicting type",
+			st:      st,
+			actor:   service,
+			opts:    []jwt.SignOption{jwt.Type("JWT")},
+			wantSub: []string{"https://service16.example.com", "https://service77.example.com"},
+			err:     nil,
+		},
+		{
+			name: "not authorized by may_act",
+			st: &oauth.AccessToken{
+				Payload: jwt.Payload{Subject: "user@example.net"},
+				MayAct:  &oauth.Actor{Subject: "https://service77.example.com"},
+			},
+			actor: service,
+			err:   oauth.ErrMayActValidation,
+		},
+		{
+			name:  "chain too deep",
+			st:    st,
+			actor: service,
+			vds: func(at *oauth.AccessToken) []jwt.Validator {
+				return []jwt.Validator{oauth.ActorChainValidator(at, 1)}
+			},
+			err: oauth.ErrActValidation,
+		},
+		{
+			name:  "actor not allowed",
+			st:    st,
+			actor: service,
+			vds: func(at *oauth.AccessToken) []jwt.Validator {
+				return []jwt.Validator{oauth.ActorValidator(at, func(act *oauth.Actor) bool {
+					return act.Subject == "https://service16.example.com"
+				})}
+			},
+			err: oauth.ErrActValidation,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			at := oauth.AccessToken{
+				Payload: jwt.Payload{
+					Issuer:         issuer,
+					Audience:       jwt.Audience{"https://backend.example.com"},
+					ExpirationTime: jwt.NumericDate(now.Add(time.Hour)),
+				},
+			}
+			var (
+				got oauth.AccessToken
+				vds []jwt.Validator
+			)
+			if tc.vds != nil {
+				vds = tc.vds(&got)
+			}
+			token, err := oauth.Exchange(tc.st, tc.actor, at, hs256, tc.opts...)
+			if err == nil {
+				_, err = jwt.Verify(token, hs256, &got, jwt.ValidateType(oauth.AccessTokenType), jwt.ValidatePayload(&got.Payload, vds...))
+			}
+			if want, got := tc.err, err; !internal.ErrorIs(got, want) {
+				t.Fatalf("oauth.Exchange err mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+			if err != nil {
+				return
+			}
+			if want, got := tc.st.Subject, got.Subject; got != want {
+				t.Errorf("sub mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+			var subs []string
+			for _, act := range got.Actor.Chain() {
+				subs = append(subs, act.Subject)
+			}
+			if want, got := tc.wantSub, subs; !cmp.Equal(got, want) {
+				t.Errorf("actor chain mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+			if got.MayAct != nil {
+				t.Errorf("may_act claim was not cleared")
+			}
+		})
+	}
+}
+
+func TestMayActValidator(t *testing.T) {
+	testCases := []struct {
+		name   string
+		mayAct *oauth.Actor
+		actor  *oauth.Actor
+		err    error
+	}{
+		{"same subject", &oauth.Actor{Subject: "admin@example.net"}, &oauth.Actor{Subject: "admin@example.net"}, nil},
+		{"other subject", &oauth.Actor{Subject: "admin@example.net"}, &oauth.Actor{Subject: "other@example.net"}, oauth.ErrMayActValidation},
+		{"extra issuer", &oauth.Actor{Subject: "admin@example.net"}, &oauth.Actor{Subject: "admin@example.net", Issuer: issuer}, nil},
+		{
+			"other issuer",
+			&oauth.Actor{Subject: "admin@example.net", Issuer: issuer},
+			&oauth.Actor{Subject: "admin@example.net", Issuer: "https://other.example.com"},
+			oauth.ErrMayActValidation,
+		},
+		{"missing client_id", &oauth.Actor{ClientID: "s6BhdRkqt3"}, &oauth.Actor{Subject: "admin@example.net"}, oauth.ErrMayActValidation},
+		{"empty may_act", &oauth.Actor{}, &oauth.Actor{Subject: "admin@example.net"}, oauth.ErrMayActValidation},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			at := &oauth.AccessToken{MayAct: tc.mayAct}
+			err := oauth.MayActValidator(at, tc.actor)(&at.Payload)
+			if want, got := tc.err, err; !internal.ErrorIs(got, want) {
+				t.Errorf("oauth.MayActValidator err mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+		})
+	}
+}
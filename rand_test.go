@@ -0,0 +1,68 @@
+// +build go1.26
+
+package jwt_test
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"testing"
+	"testing/cryptotest"
+
+	"github.com/gbrlsnchs/jwt/v3"
+	"github.com/google/go-cmp/cmp"
+)
+
+// TestGlobalRandom checks that randomized signatures only depend on crypto/rand,
+// so that they're reproducible in tests by using cryptotest.SetGlobalRandom.
+func TestGlobalRandom(t *testing.T) {
+	testCases := []struct {
+		signer, verifier jwt.Algorithm
+	}{
+		{jwt.NewES256(jwt.ECDSAPrivateKey(es256PrivateKey1)), jwt.NewES256(jwt.ECDSAPublicKey(es256PublicKey1))},
+		{jwt.NewES384(jwt.ECDSAPrivateKey(es384PrivateKey1)), jwt.NewES384(jwt.ECDSAPublicKey(es384PublicKey1))},
+		{jwt.NewES512(jwt.ECDSAPrivateKey(es512PrivateKey1)), jwt.NewES512(jwt.ECDSAPublicKey(es512PublicKey1))},
+		{jwt.NewPS256(jwt.RSAPrivateKey(rsaPrivateKey1)), jwt.NewPS256(jwt.RSAPublicKey(rsaPublicKey1))},
+		{jwt.NewPS384(jwt.RSAPrivateKey(rsaPrivateKey1)), jwt.NewPS384(jwt.RSAPublicKey(rsaPublicKey1))},
+		{jwt.NewPS512(jwt.RSAPrivateKey(rsaPrivateKey1)), jwt.NewPS512(jwt.RSAPublicKey(rsaPublicKey1))},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.signer.Name(), func(t *testing.T) {
+			var sigs [2][]byte
+			for i := range sigs {
+				cryptotest.SetGlobalRandom(t, 1)
+				sig, err := tc.signer.Sign([]byte("header.payload"))
+				if err != nil {
+					t.Fatal(err)
+				}
+				sigs[i] = sig
+			}
+			if want, got := sigs[0], sigs[1]; !bytes.Equal(got, want) {
+				t.Errorf("jwt.%s signatures mismatch (-want +got):\n%s", tc.signer.Name(), cmp.Diff(want, got))
+			}
+			if err := tc.verifier.Verify([]byte("header.payload"), []byte(base64.RawURLEncoding.EncodeToString(sigs[0]))); err != nil {
+				t.Errorf("jwt.%s.Verify err: %v", tc.signer.Name(), err)
+			}
+		})
+	}
+}
+
+type errReader struct{}
+
+func (errReader) Read([]byte) (int, error) { return 0, errors.New("no randomness") }
+
+// TestCustomRand checks that ECDSARand and RSARand are used when crypto/ecdsa and crypto/rsa allow it.
+func TestCustomRand(t *testing.T) {
+	t.Setenv("GODEBUG", "cryptocustomrand=1")
+	testCases := []jwt.Algorithm{
+		jwt.NewES256(jwt.ECDSAPrivateKey(es256PrivateKey1), jwt.ECDSARand(errReader{})),
+		jwt.NewPS256(jwt.RSAPrivateKey(rsaPrivateKey1), jwt.RSARand(errReader{})),
+	}
+	for _, alg := range testCases {
+		t.Run(alg.Name(), func(t *testing.T) {
+			if _, err := alg.Sign([]byte("header.payload")); err == nil {
+				t.Errorf("jwt.%s.Sign didn't read from the custom source of randomness", alg.Name())
+			}
+		})
+	}
+}
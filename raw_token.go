@@ -32,19 +32,24 @@ func (rt *RawToken) setToken(token []byte, sep1, sep2 int) {
 	rt.token = token
 }
 
-func (rt *RawToken) decode(payload interface{}) (err error) {
+func (rt *RawToken) decode(payload interface{}) error {
 	pb, err := internal.DecodeToBytes(rt.payload())
 	if err != nil {
 		return err
 	}
+	return rt.unmarshal(pb, payload)
+}
+
+// unmarshal decodes the payload's JSON into payload and runs validators.
+func (rt *RawToken) unmarshal(pb []byte, payload interface{}) error {
 	if !isJSONObject(pb) {
 		return ErrNotJSONObject
 	}
-	if err = json.Unmarshal(pb, payload); err != nil {
+	if err := json.Unmarshal(pb, payload); err != nil {
 		return err
 	}
 	for _, vd := range rt.vds {
-		if err = vd(rt.pl); err != nil {
+		if err := vd(rt.pl); err != nil {
 			return err
 		}
 	}
@@ -0,0 +1,73 @@
+package jwt
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/gbrlsnchs/jwt/v3/internal"
+)
+
+// AlgorithmFactory creates an algorithm from a key.
+// It should return an error wrapping ErrInvalidKey when the key's type can't be used.
+type AlgorithmFactory func(key interface{}) (Algorithm, error)
+
+// registryMu guards registry, which algorithms may be added to at runtime.
+var registryMu sync.RWMutex
+
+var registry = map[string]AlgorithmFactory{
+	"HS256":  hmacSHAFactory("HS256", NewCheckedHS256),
+	"HS384":  hmacSHAFactory("HS384", NewCheckedHS384),
+	"HS512":  hmacSHAFactory("HS512", NewCheckedHS512),
+	"RS256":  rsaSHAFactory("RS256", NewCheckedRS256),
+	"RS384":  rsaSHAFactory("RS384", NewCheckedRS384),
+	"RS512":  rsaSHAFactory("RS512", NewCheckedRS512),
+	"PS256":  rsaSHAFactory("PS256", NewCheckedPS256),
+	"PS384":  rsaSHAFactory("PS384", NewCheckedPS384),
+	"PS512":  rsaSHAFactory("PS512", NewCheckedPS512),
+	"ES256":  ecdsaSHAFactory("ES256", NewCheckedES256),
+	"ES384":  ecdsaSHAFactory("ES384", NewCheckedES384),
+	"ES512":  ecdsaSHAFactory("ES512", NewCheckedES512),
+	"ES256K": ecdsaSHAFactory("ES256K", NewCheckedES256K),
+	"EdDSA":  edDSAFactory,
+}
+
+// RegisterAlgorithm makes an algorithm available to NewAlgorithm by its "alg" header name.
+// It panics if f is nil or if an algorithm with the same name is already registered,
+// so it is meant to be called from an init function.
+func RegisterAlgorithm(name string, f AlgorithmFactory) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	if f == nil {
+		panic("jwt: RegisterAlgorithm factory is nil")
+	}
+	if _, ok := registry[name]; ok {
+		panic("jwt: RegisterAlgorithm called twice for " + name)
+	}
+	registry[name] = f
+}
+
+// Algorithms returns a sorted list of the names of the registered algorithms.
+func Algorithms() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// NewAlgorithm creates the algorithm called name, as in the "alg" header, using key.
+// HMAC algorithms require a []byte key, while other algorithms accept either
+// a private key, for signing and verifying, or a public key, for verifying only.
+// Keys are validated like by the NewChecked* constructors.
+func NewAlgorithm(name string, key interface{}) (Algorithm, error) {
+	registryMu.RLock()
+	f, ok := registry[name]
+	registryMu.RUnlock()
+	if !ok {
+		return nil, internal.Errorf("jwt: %q: %w", name, ErrUnsupportedAlg)
+	}
+	return f(key)
+}
@@ -0,0 +1,69 @@
+package jwt_test
+
+import (
+	"testing"
+
+	"github.com/gbrlsnchs/jwt/v3"
+	"github.com/gbrlsnchs/jwt/v3/internal"
+	"github.com/google/go-cmp/cmp"
+)
+
+type customAlg struct{ jwt.Algorithm }
+
+func (customAlg) Name() string { return "X-HS256" }
+
+func init() {
+	jwt.RegisterAlgorithm("X-HS256", func(key interface{}) (jwt.Algorithm, error) {
+		secret, ok := key.([]byte)
+		if !ok {
+			return nil, jwt.ErrInvalidKey
+		}
+		return customAlg{jwt.NewHS256(secret)}, nil
+	})
+}
+
+func TestRegisterAlgorithm(t *testing.T) {
+	t.Run("NewAlgorithm", func(t *testing.T) {
+		alg, err := jwt.NewAlgorithm("X-HS256", hmacKey1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		token, err := jwt.Sign(tp, alg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var pl testPayload
+		hd, err := jwt.Verify(token, alg, &pl)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want, got := "X-HS256", hd.Algorithm; got != want {
+			t.Errorf("jwt.Header.Algorithm mismatch (-want +got):\n%s", cmp.Diff(want, got))
+		}
+		_, err = jwt.NewAlgorithm("X-HS256", rsaPublicKey1)
+		if want, got := jwt.ErrInvalidKey, err; !internal.ErrorIs(got, want) {
+			t.Errorf("jwt.NewAlgorithm err mismatch (-want +got):\n%s", cmp.Diff(want, got))
+		}
+	})
+	t.Run("Algorithms", func(t *testing.T) {
+		want := []string{
+			"ES256", "ES256K", "ES384", "ES512",
+			"EdDSA",
+			"HS256", "HS384", "HS512",
+			"PS256", "PS384", "PS512",
+			"RS256", "RS384", "RS512",
+			"X-HS256",
+		}
+		if got := jwt.Algorithms(); !cmp.Equal(got, want) {
+			t.Errorf("jwt.Algorithms mismatch (-want +got):\n%s", cmp.Diff(want, got))
+		}
+	})
+	t.Run("duplicate", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Fatal("jwt.RegisterAlgorithm didn't panic")
+			}
+		}()
+		jwt.RegisterAlgorithm("HS256", func(interface{}) (jwt.Algorithm, error) { return nil, nil })
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"crypto"
 	"crypto/rand"
 	"crypto/rsa"
+	"io"
 
 	"github.com/gbrlsnchs/jwt/v3/internal"
 )
@@ -13,6 +14,12 @@ var (
 	ErrRSANilPrivKey = internal.NewError("jwt: RSA private key is nil")
 	// ErrRSANilPubKey is the error for trying to verify a JWT with a nil public key.
 	ErrRSANilPubKey = internal.NewError("jwt: RSA public key is nil")
+	// ErrRSAKeyTooShort is the error for an RSA key smaller than 2048 bits, as per the RFC 7518.
+	ErrRSAKeyTooShort = internal.NewError("jwt: RSA key is too short")
+	// ErrRSAKeyMismatch is the error for an RSA private key that doesn't match the public key it is set with.
+	ErrRSAKeyMismatch = internal.NewError("jwt: RSA private key doesn't match public key")
+	// ErrRSASaltLength is the error for an invalid RSA-PSS salt length or one the key is too short for.
+	ErrRSASaltLength = internal.NewError("jwt: RSA-PSS salt length is invalid")
 	// ErrRSAVerification is the error for an invalid RSA signature.
 	ErrRSAVerification = internal.NewError("jwt: RSA verification failed")
 
@@ -33,6 +40,41 @@ func RSAPublicKey(pub *rsa.PublicKey) func(*RSASHA) {
 	}
 }
 
+// RSARand is an option to set the source of randomness for salts when signing with RSA-PSS-SHA,
+// which defaults to crypto/rand.Reader. As of Go 1.26, crypto/rsa ignores r unless
+// GODEBUG=cryptocustomrand=1 is set. RSA-SHA signatures are deterministic and don't use it.
+func RSARand(r io.Reader) func(*RSASHA) {
+	return func(rs *RSASHA) {
+		rs.rand = r
+	}
+}
+
+// RSASignSaltLength is an option to set the salt length in bytes for signing with RSA-PSS-SHA.
+// It defaults to rsa.PSSSaltLengthAuto, which uses the maximal length the key allows,
+// while rsa.PSSSaltLengthEqualsHash uses the hash size, as expected by the RFC 7518.
+func RSASignSaltLength(n int) func(*RSASHA) {
+	return func(rs *RSASHA) {
+		rs.signSalt = n
+	}
+}
+
+// RSAVerifySaltLength is an option to set the salt length in bytes expected when verifying with RSA-PSS-SHA.
+// It defaults to rsa.PSSSaltLengthAuto, which accepts any salt length.
+func RSAVerifySaltLength(n int) func(*RSASHA) {
+	return func(rs *RSASHA) {
+		rs.verifySalt = n
+	}
+}
+
+// RSAStrictSaltLength is an option for RSA-PSS-SHA to both sign with and only accept
+// salts as long as the hash size, as per the RFC 7518.
+func RSAStrictSaltLength() func(*RSASHA) {
+	return func(rs *RSASHA) {
+		rs.signSalt = rsa.PSSSaltLengthEqualsHash
+		rs.verifySalt = rsa.PSSSaltLengthEqualsHash
+	}
+}
+
 // RSASHA is an algorithm that uses RSA to sign SHA hashes.
 type RSASHA struct {
 	name string
@@ -41,10 +83,41 @@ type RSASHA struct {
 	sha  crypto.Hash
 	size int
 	pool *hashPool
-	opts *rsa.PSSOptions
+	rand io.Reader
+
+	// PSS options, where salt lengths of zero mean rsa.PSSSaltLengthAuto.
+	signSalt   int
+	verifySalt int
+	signOpts   *rsa.PSSOptions
+	verifyOpts *rsa.PSSOptions
 }
 
+// minRSABits is the minimum size of RSA keys, as per the RFC 7518.
+const minRSABits = 2048
+
 func newRSASHA(name string, opts []func(*RSASHA), sha crypto.Hash, pss bool) *RSASHA {
+	rs, err := initRSASHA(name, opts, sha, pss)
+	if err != nil {
+		panic(err)
+	}
+	return rs
+}
+
+func newCheckedRSASHA(name string, opts []func(*RSASHA), sha crypto.Hash, pss bool) (*RSASHA, error) {
+	rs, err := initRSASHA(name, opts, sha, pss)
+	if err != nil {
+		return nil, err
+	}
+	if rs.pub.N.BitLen() < minRSABits {
+		return nil, ErrRSAKeyTooShort
+	}
+	if rs.priv != nil && (rs.priv.N.Cmp(rs.pub.N) != 0 || rs.priv.E != rs.pub.E) {
+		return nil, ErrRSAKeyMismatch
+	}
+	return rs, nil
+}
+
+func initRSASHA(name string, opts []func(*RSASHA), sha crypto.Hash, pss bool) (*RSASHA, error) {
 	rs := RSASHA{
 		name: name, // cache name
 		sha:  sha,
@@ -57,18 +130,41 @@ func newRSASHA(name string, opts []func(*RSASHA), sha crypto.Hash, pss bool) *RS
 	}
 	if rs.pub == nil {
 		if rs.priv == nil {
-			panic(ErrRSANilPrivKey)
+			return nil, ErrRSANilPrivKey
 		}
 		rs.pub = &rs.priv.PublicKey
 	}
 	rs.size = rs.pub.Size() // cache size
 	if pss {
-		rs.opts = &rsa.PSSOptions{
-			SaltLength: rsa.PSSSaltLengthAuto,
-			Hash:       sha,
+		maxSalt := (rs.pub.N.BitLen()+6)/8 - sha.Size() - 2
+		for _, n := range []int{rs.signSalt, rs.verifySalt} {
+			if n < rsa.PSSSaltLengthEqualsHash || n > 0 && n > maxSalt {
+				return nil, ErrRSASaltLength
+			}
+		}
+		rs.signOpts = &rsa.PSSOptions{SaltLength: rs.signSalt, Hash: sha}
+		rs.verifyOpts = &rsa.PSSOptions{SaltLength: rs.verifySalt, Hash: sha}
+	}
+	return &rs, nil
+}
+
+func rsaSHAFactory(name string, fn func(...func(*RSASHA)) (*RSASHA, error)) AlgorithmFactory {
+	return func(key interface{}) (Algorithm, error) {
+		var opt func(*RSASHA)
+		switch key := key.(type) {
+		case *rsa.PrivateKey:
+			opt = RSAPrivateKey(key)
+		case *rsa.PublicKey:
+			opt = RSAPublicKey(key)
+		default:
+			return nil, internal.Errorf("jwt: %q: %w", name, ErrInvalidKey)
+		}
+		rs, err := fn(opt)
+		if err != nil {
+			return nil, err // don't return a typed nil
 		}
+		return rs, nil
 	}
-	return &rs
 }
 
 // NewRS256 creates a new algorithm using RSA and SHA-256.
@@ -101,11 +197,52 @@ func NewPS512(opts ...func(*RSASHA)) *RSASHA {
 	return newRSASHA("PS512", opts, crypto.SHA512, true)
 }
 
+// NewCheckedRS256 is like NewRS256, but returns an error instead of panicking
+// and rejects keys smaller than 2048 bits or not matching each other.
+func NewCheckedRS256(opts ...func(*RSASHA)) (*RSASHA, error) {
+	return newCheckedRSASHA("RS256", opts, crypto.SHA256, false)
+}
+
+// NewCheckedRS384 is like NewRS384, but returns an error instead of panicking
+// and rejects keys smaller than 2048 bits or not matching each other.
+func NewCheckedRS384(opts ...func(*RSASHA)) (*RSASHA, error) {
+	return newCheckedRSASHA("RS384", opts, crypto.SHA384, false)
+}
+
+// NewCheckedRS512 is like NewRS512, but returns an error instead of panicking
+// and rejects keys smaller than 2048 bits or not matching each other.
+func NewCheckedRS512(opts ...func(*RSASHA)) (*RSASHA, error) {
+	return newCheckedRSASHA("RS512", opts, crypto.SHA512, false)
+}
+
+// NewCheckedPS256 is like NewPS256, but returns an error instead of panicking
+// and rejects keys smaller than 2048 bits or not matching each other.
+func NewCheckedPS256(opts ...func(*RSASHA)) (*RSASHA, error) {
+	return newCheckedRSASHA("PS256", opts, crypto.SHA256, true)
+}
+
+// NewCheckedPS384 is like NewPS384, but returns an error instead of panicking
+// and rejects keys smaller than 2048 bits or not matching each other.
+func NewCheckedPS384(opts ...func(*RSASHA)) (*RSASHA, error) {
+	return newCheckedRSASHA("PS384", opts, crypto.SHA384, true)
+}
+
+// NewCheckedPS512 is like NewPS512, but returns an error instead of panicking
+// and rejects keys smaller than 2048 bits or not matching each other.
+func NewCheckedPS512(opts ...func(*RSASHA)) (*RSASHA, error) {
+	return newCheckedRSASHA("PS512", opts, crypto.SHA512, true)
+}
+
 // Name returns the algorithm's name.
 func (rs *RSASHA) Name() string {
 	return rs.name
 }
 
+// Public returns the RSA public key.
+func (rs *RSASHA) Public() crypto.PublicKey {
+	return rs.pub
+}
+
 // Sign signs headerPayload using either RSA-SHA or RSA-PSS-SHA algorithms.
 func (rs *RSASHA) Sign(headerPayload []byte) ([]byte, error) {
 	if rs.priv == nil {
@@ -115,8 +252,12 @@ func (rs *RSASHA) Sign(headerPayload []byte) ([]byte, error) {
 	if err != nil {
 		return nil, err
 	}
-	if rs.opts != nil {
-		return rsa.SignPSS(rand.Reader, rs.priv, rs.sha, sum, rs.opts)
+	if rs.signOpts != nil {
+		rnd := rs.rand
+		if rnd == nil {
+			rnd = rand.Reader
+		}
+		return rsa.SignPSS(rnd, rs.priv, rs.sha, sum, rs.signOpts)
 	}
 	return rsa.SignPKCS1v15(rand.Reader, rs.priv, rs.sha, sum)
 }
@@ -138,8 +279,8 @@ func (rs *RSASHA) Verify(headerPayload, sig []byte) (err error) {
 	if err != nil {
 		return err
 	}
-	if rs.opts != nil {
-		err = rsa.VerifyPSS(rs.pub, rs.sha, sum, sig, rs.opts)
+	if rs.verifyOpts != nil {
+		err = rsa.VerifyPSS(rs.pub, rs.sha, sum, sig, rs.verifyOpts)
 	} else {
 		err = rsa.VerifyPKCS1v15(rs.pub, rs.sha, sum, sig)
 	}
@@ -3,7 +3,6 @@ package jwt_test
 import (
 	"crypto/rand"
 	"crypto/rsa"
-
 	"testing"
 
 	"github.com/gbrlsnchs/jwt/v3"
@@ -458,6 +457,101 @@ func TestNewRSASHA(t *testing.T) {
 	}
 }
 
+func TestNewCheckedRSASHA(t *testing.T) {
+	weakKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keys := func(priv *rsa.PrivateKey, pub *rsa.PublicKey) func(*jwt.RSASHA) {
+		return func(rs *jwt.RSASHA) {
+			jwt.RSAPrivateKey(priv)(rs)
+			jwt.RSAPublicKey(pub)(rs)
+		}
+	}
+	testCases := []struct {
+		builder func(...func(*jwt.RSASHA)) (*jwt.RSASHA, error)
+		opts    func(*jwt.RSASHA)
+		err     error
+	}{
+		{jwt.NewCheckedRS256, nil, jwt.ErrRSANilPrivKey},
+		{jwt.NewCheckedRS256, jwt.RSAPrivateKey(rsaPrivateKey1), nil},
+		{jwt.NewCheckedRS256, jwt.RSAPublicKey(rsaPublicKey1), nil},
+		{jwt.NewCheckedRS256, jwt.RSAPrivateKey(weakKey), jwt.ErrRSAKeyTooShort},
+		{jwt.NewCheckedRS384, nil, jwt.ErrRSANilPrivKey},
+		{jwt.NewCheckedRS384, jwt.RSAPrivateKey(rsaPrivateKey1), nil},
+		{jwt.NewCheckedRS384, jwt.RSAPublicKey(&weakKey.PublicKey), jwt.ErrRSAKeyTooShort},
+		{jwt.NewCheckedRS512, nil, jwt.ErrRSANilPrivKey},
+		{jwt.NewCheckedRS512, jwt.RSAPrivateKey(rsaPrivateKey1), nil},
+		{jwt.NewCheckedRS512, jwt.RSAPrivateKey(weakKey), jwt.ErrRSAKeyTooShort},
+		{jwt.NewCheckedPS256, nil, jwt.ErrRSANilPrivKey},
+		{jwt.NewCheckedPS256, jwt.RSAPrivateKey(rsaPrivateKey1), nil},
+		{jwt.NewCheckedPS256, jwt.RSAPrivateKey(weakKey), jwt.ErrRSAKeyTooShort},
+		{jwt.NewCheckedPS384, nil, jwt.ErrRSANilPrivKey},
+		{jwt.NewCheckedPS384, jwt.RSAPublicKey(rsaPublicKey1), nil},
+		{jwt.NewCheckedPS384, jwt.RSAPrivateKey(weakKey), jwt.ErrRSAKeyTooShort},
+		{jwt.NewCheckedPS512, nil, jwt.ErrRSANilPrivKey},
+		{jwt.NewCheckedPS512, jwt.RSAPrivateKey(rsaPrivateKey1), nil},
+		{jwt.NewCheckedPS512, jwt.RSAPrivateKey(weakKey), jwt.ErrRSAKeyTooShort},
+		{jwt.NewCheckedRS256, keys(rsaPrivateKey1, rsaPublicKey1), nil},
+		{jwt.NewCheckedRS256, keys(rsaPrivateKey1, rsaPublicKey2), jwt.ErrRSAKeyMismatch},
+		{jwt.NewCheckedPS256, keys(rsaPrivateKey2, rsaPublicKey1), jwt.ErrRSAKeyMismatch},
+	}
+	for _, tc := range testCases {
+		funcName := funcName(tc.builder)
+		t.Run(funcName, func(t *testing.T) {
+			_, err := tc.builder(tc.opts)
+			if want, got := tc.err, err; !internal.ErrorIs(got, want) {
+				t.Errorf("jwt.%s err mismatch (-want +got):\n%s", funcName, cmp.Diff(want, got))
+			}
+		})
+	}
+}
+
+func TestRSASaltLength(t *testing.T) {
+	testCases := []struct {
+		name       string
+		signOpts   []func(*jwt.RSASHA)
+		verifyOpts []func(*jwt.RSASHA)
+		err        error
+	}{
+		{"auto", nil, nil, nil},
+		{"auto and strict", nil, []func(*jwt.RSASHA){jwt.RSAStrictSaltLength()}, jwt.ErrRSAVerification},
+		{"strict", []func(*jwt.RSASHA){jwt.RSAStrictSaltLength()}, []func(*jwt.RSASHA){jwt.RSAStrictSaltLength()}, nil},
+		{"strict and auto", []func(*jwt.RSASHA){jwt.RSAStrictSaltLength()}, nil, nil},
+		{"explicit", []func(*jwt.RSASHA){jwt.RSASignSaltLength(20)}, []func(*jwt.RSASHA){jwt.RSAVerifySaltLength(20)}, nil},
+		{"explicit mismatch", []func(*jwt.RSASHA){jwt.RSASignSaltLength(20)}, []func(*jwt.RSASHA){jwt.RSAVerifySaltLength(32)}, jwt.ErrRSAVerification},
+		{"explicit and strict", []func(*jwt.RSASHA){jwt.RSASignSaltLength(20)}, []func(*jwt.RSASHA){jwt.RSAStrictSaltLength()}, jwt.ErrRSAVerification},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			signer := jwt.NewPS256(append(tc.signOpts, jwt.RSAPrivateKey(rsaPrivateKey1))...)
+			token, err := jwt.Sign(tp, signer)
+			if err != nil {
+				t.Fatal(err)
+			}
+			verifier := jwt.NewPS256(append(tc.verifyOpts, jwt.RSAPublicKey(rsaPublicKey1))...)
+			var pl testPayload
+			_, err = jwt.Verify(token, verifier, &pl)
+			if want, got := tc.err, err; !internal.ErrorIs(got, want) {
+				t.Errorf("jwt.Verify err mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+		})
+	}
+
+	t.Run("invalid", func(t *testing.T) {
+		for _, opt := range []func(*jwt.RSASHA){
+			jwt.RSASignSaltLength(-2),
+			jwt.RSASignSaltLength(rsaPublicKey1.Size()),
+			jwt.RSAVerifySaltLength(-2),
+		} {
+			_, err := jwt.NewCheckedPS256(jwt.RSAPrivateKey(rsaPrivateKey1), opt)
+			if want, got := jwt.ErrRSASaltLength, err; !internal.ErrorIs(got, want) {
+				t.Errorf("jwt.NewCheckedPS256 err mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+		}
+	})
+}
+
 func genRSAKeys() (*rsa.PrivateKey, *rsa.PublicKey) {
 	priv, err := rsa.GenerateKey(rand.Reader, 2048)
 	if err != nil {
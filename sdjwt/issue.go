@@ -0,0 +1,193 @@
+package sdjwt
+
+import (
+	"crypto/rand"
+	"math/big"
+	"sort"
+
+	"github.com/gbrlsnchs/jwt/v3"
+	"github.com/gbrlsnchs/jwt/v3/internal"
+)
+
+// ErrReservedClaim is the error for claims whose names are reserved by the SD-JWT format.
+var ErrReservedClaim = internal.NewError("sdjwt: claim name is reserved")
+
+// Disclosable wraps a claim value or array element that is selectively disclosable.
+type Disclosable struct {
+	Value interface{}
+}
+
+// SD marks v as selectively disclosable.
+// It may be used both for values in claim maps and for elements in arrays.
+func SD(v interface{}) Disclosable {
+	return Disclosable{Value: v}
+}
+
+type issuer struct {
+	decoys    int
+	holderKey *jwt.JWK
+	signOpts  []jwt.SignOption
+
+	disclosures []*Disclosure
+}
+
+// IssueOption is a functional option for issuing SD-JWTs.
+type IssueOption func(*issuer)
+
+// Decoys sets the number of decoy digests added to every object and array
+// containing selectively disclosable values, which hides how many of them exist.
+func Decoys(n int) IssueOption {
+	return func(is *issuer) {
+		is.decoys = n
+	}
+}
+
+// HolderKey binds the SD-JWT to the holder's public key using the "cnf" claim,
+// which enables key binding when presenting it.
+func HolderKey(jwk *jwt.JWK) IssueOption {
+	return func(is *issuer) {
+		is.holderKey = jwk
+	}
+}
+
+// SignOptions sets options used when signing the issuer JWT, like jwt.Type or jwt.KeyID.
+func SignOptions(opts ...jwt.SignOption) IssueOption {
+	return func(is *issuer) {
+		is.signOpts = opts
+	}
+}
+
+// Issue creates an SD-JWT signed by alg from claims. Values wrapped with SD are replaced
+// by digests, whose disclosures are returned alongside the issuer JWT.
+// Nested objects must be of type map[string]interface{} and arrays of type []interface{}.
+func Issue(claims map[string]interface{}, alg jwt.Algorithm, opts ...IssueOption) (*SDJWT, error) {
+	var is issuer
+	for _, opt := range opts {
+		opt(&is)
+	}
+	pl, err := is.object(claims)
+	if err != nil {
+		return nil, err
+	}
+	pl[sdAlgKey] = HashAlgorithm
+	if is.holderKey != nil {
+		pl["cnf"] = jwt.Confirmation{JSONWebKey: is.holderKey}
+	}
+	token, err := jwt.Sign(pl, alg, is.signOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return &SDJWT{JWT: token, Disclosures: is.disclosures}, nil
+}
+
+func (is *issuer) object(claims map[string]interface{}) (map[string]interface{}, error) {
+	obj := make(map[string]interface{}, len(claims))
+	var digests []string
+	for name, v := range claims {
+		if name == sdKey || name == sdAlgKey || name == ellipsis {
+			return nil, internal.Errorf("sdjwt: %q: %w", name, ErrReservedClaim)
+		}
+		sd, ok := v.(Disclosable)
+		if ok {
+			v = sd.Value
+		}
+		v, err := is.value(v)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			obj[name] = v
+			continue
+		}
+		d, err := newDisclosure(name, v, false)
+		if err != nil {
+			return nil, err
+		}
+		is.disclosures = append(is.disclosures, d)
+		digests = append(digests, d.Digest())
+	}
+	if len(digests) > 0 {
+		decoys, err := is.decoyDigests()
+		if err != nil {
+			return nil, err
+		}
+		digests = append(digests, decoys...)
+		// Sorting hides the original order of claims.
+		sort.Strings(digests)
+		obj[sdKey] = digests
+	}
+	return obj, nil
+}
+
+func (is *issuer) array(elems []interface{}) ([]interface{}, error) {
+	arr := make([]interface{}, 0, len(elems))
+	hasSD := false
+	for _, v := range elems {
+		sd, ok := v.(Disclosable)
+		if ok {
+			v = sd.Value
+		}
+		v, err := is.value(v)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			arr = append(arr, v)
+			continue
+		}
+		d, err := newDisclosure("", v, true)
+		if err != nil {
+			return nil, err
+		}
+		is.disclosures = append(is.disclosures, d)
+		arr = append(arr, map[string]string{ellipsis: d.Digest()})
+		hasSD = true
+	}
+	if hasSD && is.decoys > 0 {
+		decoys, err := is.decoyDigests()
+		if err != nil {
+			return nil, err
+		}
+		for _, dg := range decoys {
+			// Insert decoys at random positions.
+			i, err := randomIndex(len(arr) + 1)
+			if err != nil {
+				return nil, err
+			}
+			arr = append(arr, nil)
+			copy(arr[i+1:], arr[i:])
+			arr[i] = map[string]string{ellipsis: dg}
+		}
+	}
+	return arr, nil
+}
+
+func (is *issuer) value(v interface{}) (interface{}, error) {
+	switch vv := v.(type) {
+	case map[string]interface{}:
+		return is.object(vv)
+	case []interface{}:
+		return is.array(vv)
+	}
+	return v, nil
+}
+
+func (is *issuer) decoyDigests() ([]string, error) {
+	decoys := make([]string, is.decoys)
+	for i := range decoys {
+		salt, err := internal.RandomID(saltLength)
+		if err != nil {
+			return nil, err
+		}
+		decoys[i] = digest(salt)
+	}
+	return decoys, nil
+}
+
+func randomIndex(n int) (int, error) {
+	i, err := rand.Int(rand.Reader, big.NewInt(int64(n)))
+	if err != nil {
+		return 0, err
+	}
+	return int(i.Int64()), nil
+}
@@ -0,0 +1,78 @@
+package sdjwt
+
+import (
+	"time"
+
+	"github.com/gbrlsnchs/jwt/v3"
+)
+
+// KeyBindingPayload is the payload of a key binding JWT.
+type KeyBindingPayload struct {
+	jwt.Payload
+	Nonce  string `json:"nonce"`
+	SDHash string `json:"sd_hash"`
+}
+
+type presentation struct {
+	alg      jwt.Algorithm
+	aud      string
+	nonce    string
+	now      time.Time
+	signOpts []jwt.SignOption
+}
+
+// PresentOption is a functional option for creating presentations.
+type PresentOption func(*presentation)
+
+// KeyBinding appends a key binding JWT signed by alg, which must use the holder's key,
+// to a presentation intended for aud. The nonce is provided by the verifier.
+func KeyBinding(alg jwt.Algorithm, aud, nonce string, opts ...jwt.SignOption) PresentOption {
+	return func(p *presentation) {
+		p.alg = alg
+		p.aud = aud
+		p.nonce = nonce
+		p.signOpts = opts
+	}
+}
+
+// PresentedAt sets the "iat" claim of the key binding JWT. Defaults to time.Now.
+func PresentedAt(t time.Time) PresentOption {
+	return func(p *presentation) {
+		p.now = t
+	}
+}
+
+// Present creates a presentation revealing only the disclosures for which disclose returns true.
+// When a nested claim is revealed, the disclosures containing it must also be revealed.
+func (sd *SDJWT) Present(disclose func(*Disclosure) bool, opts ...PresentOption) (*SDJWT, error) {
+	var p presentation
+	for _, opt := range opts {
+		opt(&p)
+	}
+	pres := SDJWT{JWT: sd.JWT}
+	for _, d := range sd.Disclosures {
+		if disclose != nil && disclose(d) {
+			pres.Disclosures = append(pres.Disclosures, d)
+		}
+	}
+	if p.alg == nil {
+		return &pres, nil
+	}
+	if p.now.IsZero() {
+		p.now = time.Now()
+	}
+	kb := KeyBindingPayload{
+		Payload: jwt.Payload{
+			Audience: jwt.Audience{p.aud},
+			IssuedAt: jwt.NumericDate(p.now),
+		},
+		Nonce:  p.nonce,
+		SDHash: digest(string(pres.withoutKeyBinding())),
+	}
+	token, err := jwt.Sign(kb, p.alg, append(p.signOpts[:len(p.signOpts):len(p.signOpts)], jwt.Type(KeyBindingType))...)
+	if err != nil {
+		return nil, err
+	}
+	pres.KeyBinding = token
+	return &pres, nil
+}
@@ -0,0 +1,174 @@
+// Package sdjwt implements Selective Disclosure for JWTs (SD-JWT).
+//
+// An SD-JWT is serialized as the issuer-signed JWT followed by its disclosures
+// and an optional key binding JWT, all separated by tildes:
+//
+//	<JWT>~<Disclosure 1>~...~<Disclosure N>~<KB-JWT>
+package sdjwt
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+
+	"github.com/gbrlsnchs/jwt/v3/internal"
+)
+
+const (
+	// KeyBindingType is the media type for key binding JWTs.
+	KeyBindingType = "kb+jwt"
+	// HashAlgorithm is the only supported value for the "_sd_alg" claim.
+	HashAlgorithm = "sha-256"
+
+	sdKey      = "_sd"
+	sdAlgKey   = "_sd_alg"
+	ellipsis   = "..."
+	separator  = '~'
+	saltLength = 16
+)
+
+var (
+	// ErrMalformed is the error for an SD-JWT that can't be parsed.
+	ErrMalformed = internal.NewError("sdjwt: malformed SD-JWT")
+	// ErrDisclosure is the error for an invalid disclosure.
+	ErrDisclosure = internal.NewError("sdjwt: invalid disclosure")
+)
+
+// Disclosure is a salted claim or array element that is only revealed when presented.
+type Disclosure struct {
+	Salt string
+	// Name is the claim name. It is empty for array elements.
+	Name         string
+	Value        interface{}
+	ArrayElement bool
+
+	encoded string
+}
+
+func newDisclosure(name string, value interface{}, arrayElement bool) (*Disclosure, error) {
+	salt, err := internal.RandomID(saltLength)
+	if err != nil {
+		return nil, err
+	}
+	arr := []interface{}{salt, name, value}
+	if arrayElement {
+		arr = []interface{}{salt, value}
+	}
+	b, err := json.Marshal(arr)
+	if err != nil {
+		return nil, err
+	}
+	return &Disclosure{
+		Salt:         salt,
+		Name:         name,
+		Value:        value,
+		ArrayElement: arrayElement,
+		encoded:      base64.RawURLEncoding.EncodeToString(b),
+	}, nil
+}
+
+// ParseDisclosure decodes a Base64 encoded disclosure.
+func ParseDisclosure(encoded string) (*Disclosure, error) {
+	b, err := internal.DecodeToBytes([]byte(encoded))
+	if err != nil {
+		return nil, internal.Errorf("sdjwt: %v: %w", err, ErrDisclosure)
+	}
+	var arr []interface{}
+	if err = decodeJSON(b, &arr); err != nil {
+		return nil, internal.Errorf("sdjwt: %v: %w", err, ErrDisclosure)
+	}
+	d := Disclosure{encoded: encoded}
+	var ok bool
+	switch len(arr) {
+	case 2:
+		d.ArrayElement = true
+		d.Value = arr[1]
+	case 3:
+		if d.Name, ok = arr[1].(string); !ok || d.Name == sdKey || d.Name == ellipsis {
+			return nil, ErrDisclosure
+		}
+		d.Value = arr[2]
+	default:
+		return nil, ErrDisclosure
+	}
+	if d.Salt, ok = arr[0].(string); !ok {
+		return nil, ErrDisclosure
+	}
+	return &d, nil
+}
+
+// String returns the Base64 encoded disclosure.
+func (d *Disclosure) String() string {
+	return d.encoded
+}
+
+// Digest returns the SHA-256 digest of the disclosure, which is what the issuer signs.
+func (d *Disclosure) Digest() string {
+	return digest(d.encoded)
+}
+
+func digest(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// SDJWT is an issued or presented SD-JWT.
+type SDJWT struct {
+	// JWT is the issuer-signed JWT.
+	JWT         []byte
+	Disclosures []*Disclosure
+	// KeyBinding is the key binding JWT, which is only present in presentations.
+	KeyBinding []byte
+}
+
+// Parse parses a serialized SD-JWT. Signatures are not verified.
+func Parse(b []byte) (*SDJWT, error) {
+	parts := bytes.Split(b, []byte{separator})
+	if len(parts) < 2 || len(parts[0]) == 0 {
+		return nil, ErrMalformed
+	}
+	sd := SDJWT{JWT: parts[0]}
+	last := len(parts) - 1
+	if kb := parts[last]; len(kb) > 0 {
+		sd.KeyBinding = kb
+	}
+	for _, p := range parts[1:last] {
+		if len(p) == 0 {
+			return nil, ErrMalformed
+		}
+		d, err := ParseDisclosure(string(p))
+		if err != nil {
+			return nil, err
+		}
+		sd.Disclosures = append(sd.Disclosures, d)
+	}
+	return &sd, nil
+}
+
+// Bytes serializes the SD-JWT.
+func (sd *SDJWT) Bytes() []byte {
+	return append(sd.withoutKeyBinding(), sd.KeyBinding...)
+}
+
+// String serializes the SD-JWT.
+func (sd *SDJWT) String() string {
+	return string(sd.Bytes())
+}
+
+// withoutKeyBinding serializes the SD-JWT up to its last separator,
+// which is also the input for the "sd_hash" claim.
+func (sd *SDJWT) withoutKeyBinding() []byte {
+	size := len(sd.JWT) + 1
+	for _, d := range sd.Disclosures {
+		size += len(d.encoded) + 1
+	}
+	b := make([]byte, 0, size+len(sd.KeyBinding))
+	b = append(b, sd.JWT...)
+	b = append(b, separator)
+	for _, d := range sd.Disclosures {
+		b = append(b, d.encoded...)
+		b = append(b, separator)
+	}
+	return b
+}
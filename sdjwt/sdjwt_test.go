@@ -0,0 +1,63 @@
+package sdjwt_test
+
+import (
+	"testing"
+
+	"github.com/gbrlsnchs/jwt/v3/internal"
+	"github.com/gbrlsnchs/jwt/v3/sdjwt"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseDisclosure(t *testing.T) {
+	testCases := []struct {
+		encoded      string
+		name         string
+		value        interface{}
+		arrayElement bool
+		digest       string
+		err          error
+	}{
+		{
+			// Example from the SD-JWT specification.
+			encoded: "WyJfMjZiYzRMVC1hYzZxMktJNmNCVzVlcyIsICJmYW1pbHlfbmFtZSIsICJNw7ZiaXVzIl0",
+			name:    "family_name",
+			value:   "Möbius",
+			digest:  "X9yH0Ajrdm1Oij4tWso9UzzKJvPoDxwmuEcO3XAdRC0",
+			err:     nil,
+		},
+		{
+			encoded:      "WyJsa2x4RjVqTVlsR1RQVW92TU5JdkNBIiwgIkZSIl0",
+			value:        "FR",
+			arrayElement: true,
+			digest:       "w0I8EKcdCtUPkGCNUrfwVp2xEgNjtoIDlOxc9-PlOhs",
+			err:          nil,
+		},
+		{encoded: "WyJzYWx0Il0", err: sdjwt.ErrDisclosure},                 // ["salt"]
+		{encoded: "WyJzYWx0IiwgIl9zZCIsIHt9XQ", err: sdjwt.ErrDisclosure},  // ["salt", "_sd", {}]
+		{encoded: "WzEsICJuYW1lIiwgInZhbHVlIl0", err: sdjwt.ErrDisclosure}, // [1, "name", "value"]
+		{encoded: "not Base64", err: sdjwt.ErrDisclosure},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.encoded, func(t *testing.T) {
+			d, err := sdjwt.ParseDisclosure(tc.encoded)
+			if want, got := tc.err, err; !internal.ErrorIs(got, want) {
+				t.Fatalf("sdjwt.ParseDisclosure err mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+			if err != nil {
+				return
+			}
+			if want, got := tc.name, d.Name; got != want {
+				t.Errorf("sdjwt.Disclosure.Name mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+			if want, got := tc.value, d.Value; !cmp.Equal(got, want) {
+				t.Errorf("sdjwt.Disclosure.Value mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+			if want, got := tc.arrayElement, d.ArrayElement; got != want {
+				t.Errorf("sdjwt.Disclosure.ArrayElement mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+			if want, got := tc.digest, d.Digest(); got != want {
+				t.Errorf("sdjwt.Disclosure.Digest mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+		})
+	}
+}
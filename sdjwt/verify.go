@@ -0,0 +1,246 @@
+package sdjwt
+
+import (
+	"bytes"
+	"encoding/json"
+	"time"
+
+	"github.com/gbrlsnchs/jwt/v3"
+	"github.com/gbrlsnchs/jwt/v3/internal"
+	"github.com/gbrlsnchs/jwt/v3/internal/pubalg"
+	"github.com/gbrlsnchs/jwt/v3/jwtutil"
+)
+
+const defaultMaxAge = 5 * time.Minute
+
+var (
+	// ErrSDAlg is the error for an unsupported "_sd_alg" claim.
+	ErrSDAlg = internal.NewError("sdjwt: unsupported _sd_alg claim")
+	// ErrKeyBinding is the error for a missing or invalid key binding JWT.
+	ErrKeyBinding = internal.NewError("sdjwt: key binding JWT is invalid")
+)
+
+// Verifier verifies SD-JWTs and presentations.
+type Verifier struct {
+	// RequireKeyBinding rejects presentations without a key binding JWT.
+	RequireKeyBinding bool
+	// Audience is the expected "aud" claim of the key binding JWT.
+	Audience string
+	// Nonce is the expected "nonce" claim of the key binding JWT.
+	Nonce string
+	// MaxAge is for how long after its "iat" claim a key binding JWT is accepted.
+	// Defaults to 5 minutes.
+	MaxAge time.Duration
+	// Leeway is the tolerated clock skew between holder and verifier.
+	Leeway time.Duration
+	// Now returns the current time. Defaults to time.Now.
+	Now func() time.Time
+}
+
+// Verify verifies the issuer JWT of token with alg, checks every disclosure is referenced
+// by a digest and decodes the reconstructed claims into payload. Validators are run
+// against the reconstructed claims.
+func (v *Verifier) Verify(token []byte, alg jwt.Algorithm, payload interface{}, vds ...jwt.Validator) (jwt.Header, error) {
+	sd, err := Parse(token)
+	if err != nil {
+		return jwt.Header{}, err
+	}
+	var raw json.RawMessage
+	hd, err := jwt.Verify(sd.JWT, alg, &raw, jwt.ValidateHeader)
+	if err != nil {
+		return hd, err
+	}
+	var claims map[string]interface{}
+	if err = decodeJSON(raw, &claims); err != nil {
+		return hd, err
+	}
+	if sdAlg, ok := claims[sdAlgKey]; ok && sdAlg != HashAlgorithm {
+		return hd, ErrSDAlg
+	}
+	delete(claims, sdAlgKey)
+
+	r := reconstructor{
+		byDigest: make(map[string]*Disclosure, len(sd.Disclosures)),
+		used:     make(map[string]bool, len(sd.Disclosures)),
+	}
+	for _, d := range sd.Disclosures {
+		dg := d.Digest()
+		if _, ok := r.byDigest[dg]; ok {
+			return hd, ErrDisclosure
+		}
+		r.byDigest[dg] = d
+	}
+	if claims, err = r.object(claims); err != nil {
+		return hd, err
+	}
+	if len(r.used) != len(r.byDigest) {
+		return hd, internal.Errorf("sdjwt: unreferenced disclosure: %w", ErrDisclosure)
+	}
+
+	b, err := json.Marshal(claims)
+	if err != nil {
+		return hd, err
+	}
+	var pl struct {
+		jwt.Payload
+		Confirmation *jwt.Confirmation `json:"cnf,omitempty"`
+	}
+	if err = json.Unmarshal(b, &pl); err != nil {
+		return hd, err
+	}
+	if sd.KeyBinding != nil || v.RequireKeyBinding {
+		if err = v.verifyKeyBinding(sd, pl.Confirmation); err != nil {
+			return hd, err
+		}
+	}
+	for _, vd := range vds {
+		if err = vd(&pl.Payload); err != nil {
+			return hd, err
+		}
+	}
+	return hd, json.Unmarshal(b, payload)
+}
+
+func (v *Verifier) verifyKeyBinding(sd *SDJWT, cnf *jwt.Confirmation) error {
+	if sd.KeyBinding == nil || cnf == nil || cnf.JSONWebKey == nil {
+		return ErrKeyBinding
+	}
+	now := time.Now()
+	if v.Now != nil {
+		now = v.Now()
+	}
+	maxAge := v.MaxAge
+	if maxAge == 0 {
+		maxAge = defaultMaxAge
+	}
+	rv := &jwtutil.Resolver{New: func(hd jwt.Header) (jwt.Algorithm, error) {
+		return pubalg.FromJWK(hd.Algorithm, cnf.JSONWebKey)
+	}}
+	var kb KeyBindingPayload
+	_, err := jwt.Verify(sd.KeyBinding, rv, &kb,
+		jwt.ValidateType(KeyBindingType),
+		jwt.ValidatePayload(&kb.Payload, func(pl *jwt.Payload) error {
+			switch {
+			case pl.IssuedAt == nil,
+				pl.IssuedAt.After(now.Add(v.Leeway)),
+				pl.IssuedAt.Before(now.Add(-maxAge - v.Leeway)):
+				return jwt.ErrIatValidation
+			case v.Audience != "" && !(len(pl.Audience) == 1 && pl.Audience[0] == v.Audience):
+				return jwt.ErrAudValidation
+			case kb.Nonce != v.Nonce:
+				return internal.Errorf("sdjwt: nonce mismatch: %w", ErrKeyBinding)
+			case kb.SDHash != digest(string(sd.withoutKeyBinding())):
+				return internal.Errorf("sdjwt: sd_hash mismatch: %w", ErrKeyBinding)
+			}
+			return nil
+		}),
+	)
+	return err
+}
+
+type reconstructor struct {
+	byDigest map[string]*Disclosure
+	used     map[string]bool
+}
+
+func (r *reconstructor) object(obj map[string]interface{}) (map[string]interface{}, error) {
+	out := make(map[string]interface{}, len(obj))
+	for k, v := range obj {
+		if k == sdKey {
+			continue
+		}
+		v, err := r.value(v)
+		if err != nil {
+			return nil, err
+		}
+		out[k] = v
+	}
+	sdv, ok := obj[sdKey]
+	if !ok {
+		return out, nil
+	}
+	digests, ok := sdv.([]interface{})
+	if !ok {
+		return nil, ErrMalformed
+	}
+	for _, dgv := range digests {
+		dg, ok := dgv.(string)
+		if !ok {
+			return nil, ErrMalformed
+		}
+		d, err := r.disclose(dg)
+		if err != nil {
+			return nil, err
+		}
+		if d == nil {
+			continue // either undisclosed or a decoy
+		}
+		if _, exists := out[d.Name]; d.ArrayElement || exists {
+			return nil, ErrDisclosure
+		}
+		if out[d.Name], err = r.value(d.Value); err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+func (r *reconstructor) array(arr []interface{}) ([]interface{}, error) {
+	out := make([]interface{}, 0, len(arr))
+	for _, v := range arr {
+		if m, ok := v.(map[string]interface{}); ok && len(m) == 1 {
+			if dgv, ok := m[ellipsis]; ok {
+				dg, ok := dgv.(string)
+				if !ok {
+					return nil, ErrMalformed
+				}
+				d, err := r.disclose(dg)
+				if err != nil {
+					return nil, err
+				}
+				if d == nil {
+					continue
+				}
+				if !d.ArrayElement {
+					return nil, ErrDisclosure
+				}
+				v = d.Value
+			}
+		}
+		v, err := r.value(v)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, v)
+	}
+	return out, nil
+}
+
+func (r *reconstructor) value(v interface{}) (interface{}, error) {
+	switch vv := v.(type) {
+	case map[string]interface{}:
+		return r.object(vv)
+	case []interface{}:
+		return r.array(vv)
+	}
+	return v, nil
+}
+
+// disclose returns the disclosure for dg, if any, making sure it is only referenced once.
+func (r *reconstructor) disclose(dg string) (*Disclosure, error) {
+	d, ok := r.byDigest[dg]
+	if !ok {
+		return nil, nil
+	}
+	if r.used[dg] {
+		return nil, internal.Errorf("sdjwt: digest referenced twice: %w", ErrDisclosure)
+	}
+	r.used[dg] = true
+	return d, nil
+}
+
+func decodeJSON(b []byte, v interface{}) error {
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	return dec.Decode(v)
+}
@@ -0,0 +1,209 @@
+package sdjwt_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gbrlsnchs/jwt/v3"
+	"github.com/gbrlsnchs/jwt/v3/internal"
+	"github.com/gbrlsnchs/jwt/v3/sdjwt"
+	"github.com/google/go-cmp/cmp"
+)
+
+var (
+	issuerKey, _         = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	holderKey, _         = internal.GenerateEd25519Keys()
+	es256                = jwt.NewES256(jwt.ECDSAPrivateKey(issuerKey))
+	ed25519              = jwt.NewEd25519(jwt.Ed25519PrivateKey(holderKey))
+	otherHolderKey, _    = internal.GenerateEd25519Keys()
+	otherEd25519         = jwt.NewEd25519(jwt.Ed25519PrivateKey(otherHolderKey))
+	holderJWK, holderErr = jwt.NewJWK(holderKey)
+)
+
+func issue(t *testing.T, opts ...sdjwt.IssueOption) *sdjwt.SDJWT {
+	if holderErr != nil {
+		t.Fatal(holderErr)
+	}
+	claims := map[string]interface{}{
+		"iss":         "https://issuer.example.com",
+		"given_name":  sdjwt.SD("John"),
+		"family_name": sdjwt.SD("Doe"),
+		"address": sdjwt.SD(map[string]interface{}{
+			"street_address": sdjwt.SD("123 Main St"),
+			"country":        "US",
+		}),
+		"nationalities": []interface{}{sdjwt.SD("US"), sdjwt.SD("DE"), "BR"},
+	}
+	sd, err := sdjwt.Issue(claims, es256, append(opts, sdjwt.HolderKey(holderJWK))...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return sd
+}
+
+func byName(names ...string) func(*sdjwt.Disclosure) bool {
+	return func(d *sdjwt.Disclosure) bool {
+		for _, name := range names {
+			if d.ArrayElement && d.Value == name || !d.ArrayElement && d.Name == name {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+func TestVerify(t *testing.T) {
+	now := time.Now()
+	testCases := []struct {
+		name     string
+		issueOpt []sdjwt.IssueOption
+		disclose func(*sdjwt.Disclosure) bool
+		present  []sdjwt.PresentOption
+		verifier *sdjwt.Verifier
+		tamper   func(string) string
+		want     map[string]interface{}
+		err      error
+	}{
+		{
+			name:     "all disclosed",
+			disclose: func(*sdjwt.Disclosure) bool { return true },
+			verifier: &sdjwt.Verifier{},
+			want: map[string]interface{}{
+				"iss":           "https://issuer.example.com",
+				"given_name":    "John",
+				"family_name":   "Doe",
+				"address":       map[string]interface{}{"street_address": "123 Main St", "country": "US"},
+				"nationalities": []interface{}{"US", "DE", "BR"},
+			},
+		},
+		{
+			name:     "partially disclosed with decoys",
+			issueOpt: []sdjwt.IssueOption{sdjwt.Decoys(3)},
+			disclose: byName("given_name", "address", "DE"),
+			verifier: &sdjwt.Verifier{},
+			want: map[string]interface{}{
+				"iss":           "https://issuer.example.com",
+				"given_name":    "John",
+				"address":       map[string]interface{}{"country": "US"},
+				"nationalities": []interface{}{"DE", "BR"},
+			},
+		},
+		{
+			name:     "none disclosed",
+			disclose: nil,
+			verifier: &sdjwt.Verifier{},
+			want: map[string]interface{}{
+				"iss":           "https://issuer.example.com",
+				"nationalities": []interface{}{"BR"},
+			},
+		},
+		{
+			name:     "key binding",
+			disclose: byName("family_name"),
+			present:  []sdjwt.PresentOption{sdjwt.KeyBinding(ed25519, "https://verifier.example.com", "nonce")},
+			verifier: &sdjwt.Verifier{
+				RequireKeyBinding: true,
+				Audience:          "https://verifier.example.com",
+				Nonce:             "nonce",
+			},
+			want: map[string]interface{}{
+				"iss":           "https://issuer.example.com",
+				"family_name":   "Doe",
+				"nationalities": []interface{}{"BR"},
+			},
+		},
+		{
+			name:     "key binding with conflicting type",
+			disclose: byName("family_name"),
+			present:  []sdjwt.PresentOption{sdjwt.KeyBinding(ed25519, "https://verifier.example.com", "nonce", jwt.Type("JWT"))},
+			verifier: &sdjwt.Verifier{RequireKeyBinding: true, Nonce: "nonce"},
+			want: map[string]interface{}{
+				"iss":           "https://issuer.example.com",
+				"family_name":   "Doe",
+				"nationalities": []interface{}{"BR"},
+			},
+		},
+		{
+			name:     "missing key binding",
+			disclose: byName("family_name"),
+			verifier: &sdjwt.Verifier{RequireKeyBinding: true},
+			err:      sdjwt.ErrKeyBinding,
+		},
+		{
+			name:     "key binding with wrong key",
+			disclose: byName("family_name"),
+			present:  []sdjwt.PresentOption{sdjwt.KeyBinding(otherEd25519, "https://verifier.example.com", "nonce")},
+			verifier: &sdjwt.Verifier{RequireKeyBinding: true},
+			err:      jwt.ErrEd25519Verification,
+		},
+		{
+			name:     "key binding with wrong nonce",
+			disclose: byName("family_name"),
+			present:  []sdjwt.PresentOption{sdjwt.KeyBinding(ed25519, "https://verifier.example.com", "other")},
+			verifier: &sdjwt.Verifier{Nonce: "nonce"},
+			err:      sdjwt.ErrKeyBinding,
+		},
+		{
+			name:     "stale key binding",
+			disclose: byName("family_name"),
+			present: []sdjwt.PresentOption{
+				sdjwt.KeyBinding(ed25519, "https://verifier.example.com", "nonce"),
+				sdjwt.PresentedAt(now.Add(-time.Hour)),
+			},
+			verifier: &sdjwt.Verifier{Nonce: "nonce"},
+			err:      jwt.ErrIatValidation,
+		},
+		{
+			name:     "disclosure removed after key binding",
+			disclose: byName("family_name", "given_name"),
+			present:  []sdjwt.PresentOption{sdjwt.KeyBinding(ed25519, "https://verifier.example.com", "nonce")},
+			verifier: &sdjwt.Verifier{Nonce: "nonce"},
+			tamper: func(s string) string {
+				parts := strings.Split(s, "~")
+				return strings.Join(append(parts[:1], parts[2:]...), "~")
+			},
+			err: sdjwt.ErrKeyBinding,
+		},
+		{
+			name:     "unreferenced disclosure",
+			disclose: byName("family_name"),
+			verifier: &sdjwt.Verifier{},
+			tamper: func(s string) string {
+				d, _ := json.Marshal([]string{"salt", "admin", "true"})
+				return s + base64.RawURLEncoding.EncodeToString(d) + "~"
+			},
+			err: sdjwt.ErrDisclosure,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sd := issue(t, tc.issueOpt...)
+			pres, err := sd.Present(tc.disclose, tc.present...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			token := pres.String()
+			if tc.tamper != nil {
+				token = tc.tamper(token)
+			}
+			var claims map[string]interface{}
+			_, err = tc.verifier.Verify([]byte(token), es256, &claims, jwt.IssuerValidator("https://issuer.example.com"))
+			if want, got := tc.err, err; !internal.ErrorIs(got, want) {
+				t.Fatalf("sdjwt.Verifier.Verify err mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+			if err != nil {
+				return
+			}
+			delete(claims, "cnf")
+			if want, got := tc.want, claims; !cmp.Equal(got, want) {
+				t.Errorf("sdjwt.Verifier.Verify claims mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+		})
+	}
+}
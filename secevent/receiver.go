@@ -0,0 +1,93 @@
+package secevent
+
+import (
+	"encoding/json"
+	"sort"
+	"sync"
+
+	"github.com/gbrlsnchs/jwt/v3"
+)
+
+// Handler handles a single event contained in a SET.
+type Handler interface {
+	HandleEvent(set *Token, uri string, payload json.RawMessage) error
+}
+
+// HandlerFunc is an adapter to allow the use of ordinary functions as handlers.
+type HandlerFunc func(set *Token, uri string, payload json.RawMessage) error
+
+// HandleEvent calls fn.
+func (fn HandlerFunc) HandleEvent(set *Token, uri string, payload json.RawMessage) error {
+	return fn(set, uri, payload)
+}
+
+// Receiver verifies SETs and dispatches their events by URI to registered handlers.
+// Events without a registered handler are ignored.
+type Receiver struct {
+	// RejectSubject rejects SETs containing the "sub" claim, which is required
+	// by profiles that identify subjects with "sub_id" or inside events.
+	RejectSubject bool
+
+	mu       sync.RWMutex
+	handlers map[string]Handler
+}
+
+// Handle registers h for events identified by uri.
+func (rc *Receiver) Handle(uri string, h Handler) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	if rc.handlers == nil {
+		rc.handlers = make(map[string]Handler)
+	}
+	rc.handlers[uri] = h
+}
+
+// HandleFunc registers fn for events identified by uri.
+func (rc *Receiver) HandleFunc(uri string, fn func(set *Token, uri string, payload json.RawMessage) error) {
+	rc.Handle(uri, HandlerFunc(fn))
+}
+
+// Verify verifies token with alg and decodes it into set, running vds after the SET-specific validation.
+func (rc *Receiver) Verify(token []byte, alg jwt.Algorithm, set *Token, vds ...jwt.Validator) (jwt.Header, error) {
+	vds = append([]jwt.Validator{func(pl *jwt.Payload) error {
+		if err := validate(set); err != nil {
+			return err
+		}
+		if rc.RejectSubject && pl.Subject != "" {
+			return ErrSubNotAllowed
+		}
+		return nil
+	}}, vds...)
+	return jwt.Verify(token, alg, set,
+		jwt.ValidateHeader,
+		jwt.ValidateType(Type),
+		jwt.ValidatePayload(&set.Payload, vds...),
+	)
+}
+
+// Receive verifies token and dispatches its events to the registered handlers.
+// Events are dispatched in lexical order of their URIs and
+// dispatching stops at the first error returned by a handler.
+func (rc *Receiver) Receive(token []byte, alg jwt.Algorithm, vds ...jwt.Validator) (*Token, error) {
+	var set Token
+	if _, err := rc.Verify(token, alg, &set, vds...); err != nil {
+		return nil, err
+	}
+	rc.mu.RLock()
+	defer rc.mu.RUnlock()
+	uris := make([]string, 0, len(set.Events))
+	for uri := range set.Events {
+		uris = append(uris, uri)
+	}
+	sort.Strings(uris)
+	for _, uri := range uris {
+		h, ok := rc.handlers[uri]
+		if !ok {
+			continue
+		}
+		if err := h.HandleEvent(&set, uri, set.Events[uri]); err != nil {
+			return &set, err
+		}
+	}
+	return &set, nil
+}
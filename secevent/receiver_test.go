@@ -0,0 +1,114 @@
+package secevent_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gbrlsnchs/jwt/v3"
+	"github.com/gbrlsnchs/jwt/v3/internal"
+	"github.com/gbrlsnchs/jwt/v3/secevent"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestReceiver(t *testing.T) {
+	var handled []string
+	handlerErr := errors.New("handler")
+	rc := secevent.Receiver{RejectSubject: true}
+	rc.HandleFunc(credentialChange, func(set *secevent.Token, uri string, payload json.RawMessage) error {
+		var ev struct {
+			ChangeType string `json:"change_type"`
+		}
+		if err := json.Unmarshal(payload, &ev); err != nil {
+			return err
+		}
+		handled = append(handled, uri+"#"+ev.ChangeType)
+		return nil
+	})
+	rc.HandleFunc(sessionRevoked, func(set *secevent.Token, uri string, payload json.RawMessage) error {
+		handled = append(handled, uri)
+		if set.TransactionID == "fail" {
+			return handlerErr
+		}
+		return nil
+	})
+
+	sign := func(set secevent.Token, opts ...jwt.SignOption) []byte {
+		token, err := jwt.Sign(set, hs256, opts...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return token
+	}
+	typ := jwt.Type(secevent.Type)
+	withSub := newSET(t, credentialChange)
+	withSub.Subject = "someone"
+	withExp := newSET(t, credentialChange)
+	withExp.ExpirationTime = jwt.NumericDate(time.Now().Add(time.Hour))
+	failing := newSET(t, sessionRevoked)
+	failing.TransactionID = "fail"
+	testCases := []struct {
+		name    string
+		token   []byte
+		handled []string
+		err     error
+	}{
+		{
+			name:    "single event",
+			token:   sign(newSET(t, credentialChange), typ),
+			handled: []string{credentialChange + "#update"},
+			err:     nil,
+		},
+		{
+			name:    "multiple events",
+			token:   sign(newSET(t, sessionRevoked, credentialChange), typ),
+			handled: []string{credentialChange + "#update", sessionRevoked},
+			err:     nil,
+		},
+		{
+			name:    "unknown event",
+			token:   sign(newSET(t, "https://example.com/unknown"), typ),
+			handled: nil,
+			err:     nil,
+		},
+		{
+			name:  "wrong type",
+			token: sign(newSET(t, credentialChange)),
+			err:   jwt.ErrTypValidation,
+		},
+		{
+			name:  "no events",
+			token: sign(newSET(t), typ),
+			err:   secevent.ErrEventsValidation,
+		},
+		{
+			name:  "exp",
+			token: sign(withExp, typ),
+			err:   secevent.ErrExpNotAllowed,
+		},
+		{
+			name:  "sub",
+			token: sign(withSub, typ),
+			err:   secevent.ErrSubNotAllowed,
+		},
+		{
+			name:    "handler error",
+			token:   sign(failing, typ),
+			handled: []string{sessionRevoked},
+			err:     handlerErr,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			handled = nil
+			_, err := rc.Receive(tc.token, hs256, jwt.IssuerValidator("https://idp.example.com"))
+			if want, got := tc.err, err; !internal.ErrorIs(got, want) {
+				t.Fatalf("secevent.Receiver.Receive err mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+			if want, got := tc.handled, handled; !cmp.Equal(got, want) {
+				t.Errorf("handled events mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+// Package secevent implements Security Event Tokens (SETs), as per the RFC 8417.
+package secevent
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/gbrlsnchs/jwt/v3"
+	"github.com/gbrlsnchs/jwt/v3/internal"
+)
+
+// Type is the media type for SETs.
+const Type = "secevent+jwt"
+
+var (
+	// ErrEventsValidation is the error for a missing or malformed "events" claim.
+	ErrEventsValidation = internal.NewError("secevent: events claim is invalid")
+	// ErrExpNotAllowed is the error for a SET containing the "exp" claim, which
+	// would allow it to be confused with an access token or ID token.
+	ErrExpNotAllowed = internal.NewError("secevent: exp claim is not allowed")
+	// ErrSubNotAllowed is the error for a SET containing the "sub" claim
+	// when the subject must be identified otherwise.
+	ErrSubNotAllowed = internal.NewError("secevent: sub claim is not allowed")
+)
+
+// Token is a SET payload.
+type Token struct {
+	jwt.Payload
+	Events        map[string]json.RawMessage `json:"events"`
+	TransactionID string                     `json:"txn,omitempty"`
+	TimeOfEvent   *jwt.Time                  `json:"toe,omitempty"`
+	// SubjectID identifies the subject of the SET when "sub" is not used,
+	// as per the RFC 9493.
+	SubjectID *SubjectID `json:"sub_id,omitempty"`
+}
+
+// SubjectID is a subject identifier, as per the RFC 9493.
+type SubjectID struct {
+	Format string `json:"format"`
+	Email  string `json:"email,omitempty"`
+	Issuer string `json:"iss,omitempty"`
+	Sub    string `json:"sub,omitempty"`
+	ID     string `json:"id,omitempty"`
+	Phone  string `json:"phone_number,omitempty"`
+	URI    string `json:"uri,omitempty"`
+}
+
+// AddEvent marshals v and sets it as the payload of the event identified by uri.
+func (set *Token) AddEvent(uri string, v interface{}) error {
+	if v == nil {
+		v = struct{}{}
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	if set.Events == nil {
+		set.Events = make(map[string]json.RawMessage)
+	}
+	set.Events[uri] = b
+	return nil
+}
+
+// Sign signs set with alg. When not set, the "iat" and "jti" claims are generated.
+func Sign(set Token, alg jwt.Algorithm, opts ...jwt.SignOption) ([]byte, error) {
+	if err := validate(&set); err != nil {
+		return nil, err
+	}
+	if set.IssuedAt == nil {
+		set.IssuedAt = jwt.NumericDate(time.Now())
+	}
+	if set.JWTID == "" {
+		var err error
+		if set.JWTID, err = internal.RandomID(16); err != nil {
+			return nil, err
+		}
+	}
+	return jwt.Sign(set, alg, append(opts[:len(opts):len(opts)], jwt.Type(Type))...)
+}
+
+func validate(set *Token) error {
+	if len(set.Events) == 0 {
+		return ErrEventsValidation
+	}
+	for _, ev := range set.Events {
+		if !isJSONObject(ev) {
+			return ErrEventsValidation
+		}
+	}
+	if set.ExpirationTime != nil {
+		return ErrExpNotAllowed
+	}
+	return nil
+}
+
+func isJSONObject(b json.RawMessage) bool {
+	var v map[string]json.RawMessage
+	return json.Unmarshal(b, &v) == nil && v != nil
+}
@@ -0,0 +1,91 @@
+package secevent_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gbrlsnchs/jwt/v3"
+	"github.com/gbrlsnchs/jwt/v3/internal"
+	"github.com/gbrlsnchs/jwt/v3/secevent"
+	"github.com/google/go-cmp/cmp"
+)
+
+const (
+	credentialChange = "https://schemas.openid.net/secevent/caep/event-type/credential-change"
+	sessionRevoked   = "https://schemas.openid.net/secevent/caep/event-type/session-revoked"
+)
+
+var hs256 = jwt.NewHS256([]byte("secevent"))
+
+func newSET(t *testing.T, uris ...string) secevent.Token {
+	set := secevent.Token{
+		Payload: jwt.Payload{
+			Issuer:   "https://idp.example.com",
+			Audience: jwt.Audience{"https://rp.example.com"},
+		},
+		SubjectID: &secevent.SubjectID{Format: "email", Email: "someone@example.com"},
+	}
+	for _, uri := range uris {
+		if err := set.AddEvent(uri, map[string]string{"change_type": "update"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return set
+}
+
+func TestSign(t *testing.T) {
+	withExp := newSET(t, credentialChange)
+	withExp.ExpirationTime = jwt.NumericDate(time.Now().Add(time.Hour))
+	testCases := []struct {
+		name string
+		set  secevent.Token
+		err  error
+	}{
+		{"valid", newSET(t, credentialChange), nil},
+		{"multiple events", newSET(t, credentialChange, sessionRevoked), nil},
+		{"no events", newSET(t), secevent.ErrEventsValidation},
+		{"exp", withExp, secevent.ErrExpNotAllowed},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			token, err := secevent.Sign(tc.set, hs256)
+			if want, got := tc.err, err; !internal.ErrorIs(got, want) {
+				t.Fatalf("secevent.Sign err mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+			if err != nil {
+				return
+			}
+			var set secevent.Token
+			hd, err := jwt.Verify(token, hs256, &set)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want, got := secevent.Type, hd.Type; got != want {
+				t.Errorf(`"typ" mismatch (-want +got):\n%s`, cmp.Diff(want, got))
+			}
+			if set.JWTID == "" || set.IssuedAt == nil {
+				t.Errorf(`"jti" or "iat" claims are missing`)
+			}
+		})
+	}
+}
+
+func TestSignOptions(t *testing.T) {
+	opts := make([]jwt.SignOption, 1, 2)
+	opts[0] = jwt.Type("JWT")
+	token, err := secevent.Sign(newSET(t, credentialChange), hs256, opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if opts[:2][1] != nil {
+		t.Error("secevent.Sign wrote into the caller's options")
+	}
+	var set secevent.Token
+	hd, err := jwt.Verify(token, hs256, &set)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, got := secevent.Type, hd.Type; got != want {
+		t.Errorf("typ mismatch (-want +got):\n%s", cmp.Diff(want, got))
+	}
+}
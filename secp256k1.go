@@ -0,0 +1,27 @@
+package jwt
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	secp256k1ecdsa "github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+)
+
+// Secp256k1 returns an elliptic.Curve which implements secp256k1, as per the SEC 2.
+// It is the curve used by ES256K, as per the RFC 8812.
+func Secp256k1() elliptic.Curve {
+	return secp256k1.S256()
+}
+
+// signSecp256k1 signs sum with priv. Unlike crypto/ecdsa, which falls back to variable-time
+// arithmetic for curves outside the standard library, github.com/decred/dcrd/dcrec/secp256k1
+// signs in constant time. Signatures are deterministic, as per the RFC 6979, and in low-S form.
+func signSecp256k1(priv *ecdsa.PrivateKey, sum []byte) []byte {
+	var d secp256k1.ModNScalar
+	d.SetByteSlice(priv.D.Bytes())
+	key := secp256k1.NewPrivateKey(&d)
+	defer key.Zero()
+	// Compact signatures are prefixed by a recovery code, followed by r and s.
+	return secp256k1ecdsa.SignCompact(key, sum, false)[1:]
+}
@@ -1,8 +1,10 @@
 package jwt
 
 import (
+	"bytes"
 	"encoding/base64"
 	"encoding/json"
+	"sync"
 
 	"github.com/gbrlsnchs/jwt/v3/internal"
 )
@@ -17,6 +19,13 @@ func ContentType(cty string) SignOption {
 	}
 }
 
+// JSONWebKey sets the "jwk" claim for a Header before signing.
+func JSONWebKey(jwk *JWK) SignOption {
+	return func(hd *Header) {
+		hd.JSONWebKey = jwk
+	}
+}
+
 // KeyID sets the "kid" claim for a Header before signing.
 func KeyID(kid string) SignOption {
 	return func(hd *Header) {
@@ -24,8 +33,45 @@ func KeyID(kid string) SignOption {
 	}
 }
 
+// Type sets the "typ" claim for a Header before signing.
+// When not set, "typ" defaults to "JWT".
+func Type(typ string) SignOption {
+	return func(hd *Header) {
+		hd.Type = typ
+	}
+}
+
 // Sign signs a payload with alg.
 func Sign(payload interface{}, alg Algorithm, opts ...SignOption) ([]byte, error) {
+	token, err := AppendSign(nil, payload, alg, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return token, nil
+}
+
+// AppendSign is like Sign, but appends the token to dst and returns the extended buffer.
+// If dst has enough capacity, the token is written in place.
+func AppendSign(dst []byte, payload interface{}, alg Algorithm, opts ...SignOption) ([]byte, error) {
+	hb, err := signHeader(alg, opts)
+	if err != nil {
+		return dst, err
+	}
+	mb := getMarshalBuffer()
+	defer putMarshalBuffer(mb)
+	pb, err := mb.marshalPayload(payload)
+	if err != nil {
+		return dst, err
+	}
+
+	n := len(dst)
+	dst = grow(dst, base64.RawURLEncoding.EncodedLen(len(hb))+signedLen(pb, alg))
+	dst = appendEncode(dst, hb)
+	return appendSigned(dst, n, pb, alg)
+}
+
+// signHeader marshals the header resulting from applying opts for alg.
+func signHeader(alg Algorithm, opts []SignOption) ([]byte, error) {
 	var hd Header
 	for _, opt := range opts {
 		opt(&hd)
@@ -37,39 +83,94 @@ func Sign(payload interface{}, alg Algorithm, opts ...SignOption) ([]byte, error
 	}
 	// Override some values or set them if empty.
 	hd.Algorithm = alg.Name()
-	hd.Type = "JWT"
-	// Marshal the header part of the JWT.
-	hb, err := json.Marshal(hd)
+	if hd.Type == "" {
+		hd.Type = "JWT"
+	}
+	return json.Marshal(hd)
+}
+
+// signedLen is the length of the encoded payload and signature, including separators.
+func signedLen(pb []byte, alg Algorithm) int {
+	enc := base64.RawURLEncoding
+	return 1 + enc.EncodedLen(len(pb)) + 1 + enc.EncodedLen(alg.Size())
+}
+
+// appendSigned appends the encoded payload and signature to dst, whose token starts at dst[n:]
+// and already contains the encoded header. On failure, it returns dst[:n].
+func appendSigned(dst []byte, n int, pb []byte, alg Algorithm) ([]byte, error) {
+	dst = grow(dst, signedLen(pb, alg))
+	dst = append(dst, '.')
+	dst = appendEncode(dst, pb)
+	sig, err := alg.Sign(dst[n:])
 	if err != nil {
-		return nil, err
+		return dst[:n], err
+	}
+	dst = append(dst, '.')
+	return appendEncode(grow(dst, base64.RawURLEncoding.EncodedLen(len(sig))), sig), nil
+}
+
+// maxPooledMarshalBuffer is the largest buffer kept for reuse, so that
+// a single huge payload doesn't hold on to memory forever.
+const maxPooledMarshalBuffer = 64 << 10
+
+// marshalBuffer marshals JSON into memory that is reused between calls.
+type marshalBuffer struct {
+	buf bytes.Buffer
+	enc *json.Encoder
+}
+
+var marshalPool = sync.Pool{
+	New: func() interface{} {
+		mb := new(marshalBuffer)
+		mb.enc = json.NewEncoder(&mb.buf)
+		return mb
+	},
+}
+
+func getMarshalBuffer() *marshalBuffer {
+	return marshalPool.Get().(*marshalBuffer)
+}
+
+func putMarshalBuffer(mb *marshalBuffer) {
+	if mb.buf.Cap() > maxPooledMarshalBuffer {
+		return
 	}
+	marshalPool.Put(mb)
+}
 
+// marshalPayload is like json.Marshal, but the returned slice is only valid until the next call.
+// A nil payload is marshaled as an empty Payload.
+func (mb *marshalBuffer) marshalPayload(payload interface{}) ([]byte, error) {
 	if payload == nil {
 		payload = Payload{}
 	}
-	// Marshal the claims part of the JWT.
-	pb, err := json.Marshal(payload)
-	if err != nil {
+	mb.buf.Reset()
+	if err := mb.enc.Encode(payload); err != nil {
 		return nil, err
 	}
+	// Encode terminates each value with a newline.
+	pb := bytes.TrimSuffix(mb.buf.Bytes(), []byte("\n"))
 	if !isJSONObject(pb) {
 		return nil, ErrNotJSONObject
 	}
+	return pb, nil
+}
 
-	enc := base64.RawURLEncoding
-	h64len := enc.EncodedLen(len(hb))
-	p64len := enc.EncodedLen(len(pb))
-	sig64len := enc.EncodedLen(alg.Size())
-	token := make([]byte, h64len+1+p64len+1+sig64len)
-
-	enc.Encode(token, hb)
-	token[h64len] = '.'
-	enc.Encode(token[h64len+1:], pb)
-	sig, err := alg.Sign(token[:h64len+1+p64len])
-	if err != nil {
-		return nil, err
+// grow makes sure dst has room for n more bytes.
+func grow(dst []byte, n int) []byte {
+	if len(dst)+n <= cap(dst) {
+		return dst
 	}
-	token[h64len+1+p64len] = '.'
-	enc.Encode(token[h64len+1+p64len+1:], sig)
-	return token, nil
+	grown := make([]byte, len(dst), 2*cap(dst)+n)
+	copy(grown, dst)
+	return grown
+}
+
+// appendEncode appends src encoded as Base64 to dst, which must have enough capacity.
+func appendEncode(dst, src []byte) []byte {
+	enc := base64.RawURLEncoding
+	n := len(dst)
+	dst = dst[:n+enc.EncodedLen(len(src))]
+	enc.Encode(dst[n:], src)
+	return dst
 }
@@ -0,0 +1,46 @@
+package jwt
+
+import "encoding/base64"
+
+// Signer signs tokens with a fixed algorithm and header, which suits services issuing lots of tokens.
+// The header is marshaled and encoded only once, when creating the Signer, and payloads are
+// marshaled using pooled buffers. It is safe for concurrent use as long as its Algorithm is.
+type Signer struct {
+	alg    Algorithm
+	header []byte
+}
+
+// NewSigner creates a Signer that signs tokens using alg and the header resulting from opts.
+func NewSigner(alg Algorithm, opts ...SignOption) (*Signer, error) {
+	hb, err := signHeader(alg, opts)
+	if err != nil {
+		return nil, err
+	}
+	header := make([]byte, 0, base64.RawURLEncoding.EncodedLen(len(hb)))
+	return &Signer{alg: alg, header: appendEncode(header, hb)}, nil
+}
+
+// Sign signs a payload using the Signer's algorithm and header.
+func (s *Signer) Sign(payload interface{}) ([]byte, error) {
+	token, err := s.AppendSign(nil, payload)
+	if err != nil {
+		return nil, err
+	}
+	return token, nil
+}
+
+// AppendSign is like Sign, but appends the token to dst and returns the extended buffer.
+// If dst has enough capacity, the token is written in place.
+func (s *Signer) AppendSign(dst []byte, payload interface{}) ([]byte, error) {
+	mb := getMarshalBuffer()
+	defer putMarshalBuffer(mb)
+	pb, err := mb.marshalPayload(payload)
+	if err != nil {
+		return dst, err
+	}
+
+	n := len(dst)
+	dst = grow(dst, len(s.header)+signedLen(pb, s.alg))
+	dst = append(dst, s.header...)
+	return appendSigned(dst, n, pb, s.alg)
+}
@@ -0,0 +1,115 @@
+package jwt_test
+
+import (
+	"testing"
+
+	"github.com/gbrlsnchs/jwt/v3"
+	"github.com/gbrlsnchs/jwt/v3/internal"
+	"github.com/gbrlsnchs/jwt/v3/jwtutil"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestAppendSign(t *testing.T) {
+	testCases := []struct {
+		payload interface{}
+		alg     jwt.Algorithm
+		opts    []jwt.SignOption
+		err     error
+	}{
+		{tp, jwt.NewHS256(hmacKey1), nil, nil},
+		{tp, jwt.NewHS512(hmacKey1), []jwt.SignOption{jwt.KeyID("kid"), jwt.Type("at+jwt")}, nil},
+		{nil, jwt.NewHS256(hmacKey1), nil, nil},
+		{tp, &jwt.HMACSHA{}, nil, jwt.ErrHMACMissingKey},
+		{"not an object", jwt.NewHS256(hmacKey1), nil, jwt.ErrNotJSONObject},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.alg.Name(), func(t *testing.T) {
+			dst := []byte("prefix")
+			dst, err := jwt.AppendSign(dst, tc.payload, tc.alg, tc.opts...)
+			if want, got := tc.err, err; !internal.ErrorIs(got, want) {
+				t.Fatalf("jwt.AppendSign err mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+			if err != nil {
+				if want, got := "prefix", string(dst); got != want {
+					t.Errorf("jwt.AppendSign dst mismatch (-want +got):\n%s", cmp.Diff(want, got))
+				}
+				return
+			}
+			// HMAC signatures are deterministic, so both functions must produce the same token.
+			token, err := jwt.Sign(tc.payload, tc.alg, tc.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want, got := "prefix"+string(token), string(dst); got != want {
+				t.Errorf("jwt.AppendSign mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+		})
+	}
+}
+
+func TestNewSigner(t *testing.T) {
+	testCases := []struct {
+		alg jwt.Algorithm
+		err error
+	}{
+		{jwt.NewHS256(hmacKey1), nil},
+		{&jwtutil.Resolver{New: func(jwt.Header) (jwt.Algorithm, error) {
+			return jwt.NewHS256(hmacKey1), nil
+		}}, nil},
+		{&jwtutil.Resolver{New: func(jwt.Header) (jwt.Algorithm, error) {
+			return nil, testErr
+		}}, testErr},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.alg.Name(), func(t *testing.T) {
+			s, err := jwt.NewSigner(tc.alg)
+			if want, got := tc.err, err; !internal.ErrorIs(got, want) {
+				t.Fatalf("jwt.NewSigner err mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+			if err == nil && s == nil {
+				t.Fatal("jwt.NewSigner return value is nil")
+			}
+		})
+	}
+}
+
+func TestSigner(t *testing.T) {
+	testCases := map[string][]testCase{
+		"HMAC":    hmacTestCases,
+		"RSA":     rsaTestCases,
+		"RSA-PSS": rsaPSSTestCases,
+		"ECDSA":   ecdsaTestCases,
+		"Ed25519": ed25519TestCases,
+		"Ed448":   ed448TestCases,
+	}
+	for k, v := range testCases {
+		t.Run(k, func(t *testing.T) {
+			for _, tc := range v {
+				t.Run(tc.verifyAlg.Name(), func(t *testing.T) {
+					s, err := jwt.NewSigner(tc.alg)
+					if err != nil {
+						t.Fatal(err)
+					}
+					// Sign twice so that the same encoded header is reused.
+					var dst []byte
+					for i := 0; i < 2; i++ {
+						if dst, err = s.AppendSign(dst[:0], tc.payload); err != tc.signErr {
+							t.Fatalf("jwt.Signer.AppendSign err mismatch (-want +got):\n%s", cmp.Diff(tc.signErr, err))
+						}
+						var pl testPayload
+						hd, err := jwt.Verify(dst, tc.verifyAlg, &pl)
+						if want, got := tc.verifyErr, err; got != want {
+							t.Errorf("jwt.Verify err mismatch (-want +got):\n%s", cmp.Diff(want, got))
+						}
+						if want, got := tc.wantHeader, hd; !cmp.Equal(got, want) {
+							t.Errorf("jwt.Verify header mismatch (-want +got):\n%s", cmp.Diff(want, got))
+						}
+						if want, got := tc.wantPayload, pl; !cmp.Equal(got, want) {
+							t.Errorf("jwt.Verify payload mismatch (-want +got):\n%s", cmp.Diff(want, got))
+						}
+					}
+				})
+			}
+		})
+	}
+}
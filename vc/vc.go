@@ -0,0 +1,150 @@
+// Package vc implements W3C Verifiable Credentials and Presentations encoded as JWTs.
+package vc
+
+import (
+	"time"
+
+	"github.com/gbrlsnchs/jwt/v3"
+	"github.com/gbrlsnchs/jwt/v3/internal"
+)
+
+const (
+	// ContextV1 is the base context of the Verifiable Credentials Data Model v1.1.
+	ContextV1 = "https://www.w3.org/2018/credentials/v1"
+	// CredentialType is the base type of every credential.
+	CredentialType = "VerifiableCredential"
+	// PresentationType is the base type of every presentation.
+	PresentationType = "VerifiablePresentation"
+)
+
+var (
+	// ErrVCValidation is the error for a missing or invalid "vc" claim.
+	ErrVCValidation = internal.NewError("vc: vc claim is invalid")
+	// ErrVPValidation is the error for a missing or invalid "vp" claim.
+	ErrVPValidation = internal.NewError("vc: vp claim is invalid")
+)
+
+// Credential is a verifiable credential.
+type Credential struct {
+	Context           []string               `json:"@context"`
+	ID                string                 `json:"id,omitempty"`
+	Type              []string               `json:"type"`
+	Issuer            string                 `json:"issuer,omitempty"`
+	IssuanceDate      *time.Time             `json:"issuanceDate,omitempty"`
+	ExpirationDate    *time.Time             `json:"expirationDate,omitempty"`
+	CredentialSubject map[string]interface{} `json:"credentialSubject"`
+	CredentialStatus  map[string]interface{} `json:"credentialStatus,omitempty"`
+	CredentialSchema  map[string]interface{} `json:"credentialSchema,omitempty"`
+}
+
+// Presentation is a verifiable presentation whose credentials are JWTs.
+type Presentation struct {
+	Context              []string `json:"@context"`
+	ID                   string   `json:"id,omitempty"`
+	Type                 []string `json:"type"`
+	Holder               string   `json:"holder,omitempty"`
+	VerifiableCredential []string `json:"verifiableCredential,omitempty"`
+}
+
+// CredentialPayload is the JWT payload of a credential.
+// Properties that have registered claim equivalents are only encoded as those claims.
+type CredentialPayload struct {
+	jwt.Payload
+	VC *Credential `json:"vc"`
+}
+
+// PresentationPayload is the JWT payload of a presentation.
+type PresentationPayload struct {
+	jwt.Payload
+	Nonce string        `json:"nonce,omitempty"`
+	VP    *Presentation `json:"vp"`
+}
+
+// NewCredentialPayload maps cred into a JWT payload: "issuer" becomes "iss", "id" becomes "jti",
+// "issuanceDate" becomes "nbf", "expirationDate" becomes "exp" and the
+// credential subject's "id" becomes "sub".
+func NewCredentialPayload(cred Credential) CredentialPayload {
+	pl := CredentialPayload{
+		Payload: jwt.Payload{
+			Issuer: cred.Issuer,
+			JWTID:  cred.ID,
+		},
+	}
+	if cred.IssuanceDate != nil {
+		pl.NotBefore = jwt.NumericDate(*cred.IssuanceDate)
+		pl.IssuedAt = pl.NotBefore
+	}
+	if cred.ExpirationDate != nil {
+		pl.ExpirationTime = jwt.NumericDate(*cred.ExpirationDate)
+	}
+	if sub, ok := cred.CredentialSubject["id"].(string); ok {
+		pl.Subject = sub
+		subject := make(map[string]interface{}, len(cred.CredentialSubject)-1)
+		for k, v := range cred.CredentialSubject {
+			if k != "id" {
+				subject[k] = v
+			}
+		}
+		cred.CredentialSubject = subject
+	}
+	cred.Issuer = ""
+	cred.ID = ""
+	cred.IssuanceDate = nil
+	cred.ExpirationDate = nil
+	pl.VC = &cred
+	return pl
+}
+
+// Credential restores the credential from the payload's registered claims.
+func (pl *CredentialPayload) Credential() Credential {
+	var cred Credential
+	if pl.VC != nil {
+		cred = *pl.VC
+	}
+	if pl.Issuer != "" {
+		cred.Issuer = pl.Issuer
+	}
+	if pl.JWTID != "" {
+		cred.ID = pl.JWTID
+	}
+	if pl.NotBefore != nil {
+		t := pl.NotBefore.Time
+		cred.IssuanceDate = &t
+	}
+	if pl.ExpirationTime != nil {
+		t := pl.ExpirationTime.Time
+		cred.ExpirationDate = &t
+	}
+	if pl.Subject != "" {
+		subject := make(map[string]interface{}, len(cred.CredentialSubject)+1)
+		for k, v := range cred.CredentialSubject {
+			subject[k] = v
+		}
+		subject["id"] = pl.Subject
+		cred.CredentialSubject = subject
+	}
+	return cred
+}
+
+// SignCredential signs cred with the issuer's alg.
+func SignCredential(cred Credential, alg jwt.Algorithm, opts ...jwt.SignOption) ([]byte, error) {
+	return jwt.Sign(NewCredentialPayload(cred), alg, opts...)
+}
+
+// SignPresentation signs vp with the holder's alg for a verifier identified by aud.
+// The nonce is provided by the verifier in order to prevent replays.
+func SignPresentation(vp Presentation, alg jwt.Algorithm, aud, nonce string, opts ...jwt.SignOption) ([]byte, error) {
+	pl := PresentationPayload{
+		Payload: jwt.Payload{
+			Issuer:   vp.Holder,
+			Audience: jwt.Audience{aud},
+			IssuedAt: jwt.NumericDate(time.Now()),
+			JWTID:    vp.ID,
+		},
+		Nonce: nonce,
+	}
+	vp.Holder = ""
+	vp.ID = ""
+	pl.VP = &vp
+	return jwt.Sign(pl, alg, opts...)
+}
@@ -0,0 +1,49 @@
+package vc_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gbrlsnchs/jwt/v3"
+	"github.com/gbrlsnchs/jwt/v3/vc"
+	"github.com/google/go-cmp/cmp"
+)
+
+func newCredential(sub string, exp time.Time) vc.Credential {
+	iat := time.Unix(time.Now().Unix(), 0)
+	return vc.Credential{
+		Context:        []string{vc.ContextV1, "https://www.w3.org/2018/credentials/examples/v1"},
+		ID:             "http://example.edu/credentials/3732",
+		Type:           []string{vc.CredentialType, "UniversityDegreeCredential"},
+		Issuer:         "https://example.edu/issuers/14",
+		IssuanceDate:   &iat,
+		ExpirationDate: &exp,
+		CredentialSubject: map[string]interface{}{
+			"id":     sub,
+			"degree": map[string]interface{}{"type": "BachelorDegree"},
+		},
+	}
+}
+
+func TestCredentialPayload(t *testing.T) {
+	exp := time.Unix(time.Now().Add(time.Hour).Unix(), 0)
+	cred := newCredential("did:example:holder", exp)
+	pl := vc.NewCredentialPayload(cred)
+	want := jwt.Payload{
+		Issuer:         "https://example.edu/issuers/14",
+		Subject:        "did:example:holder",
+		ExpirationTime: jwt.NumericDate(exp),
+		NotBefore:      jwt.NumericDate(*cred.IssuanceDate),
+		IssuedAt:       jwt.NumericDate(*cred.IssuanceDate),
+		JWTID:          "http://example.edu/credentials/3732",
+	}
+	if got := pl.Payload; !cmp.Equal(got, want) {
+		t.Errorf("vc.NewCredentialPayload claims mismatch (-want +got):\n%s", cmp.Diff(want, got))
+	}
+	if _, ok := pl.VC.CredentialSubject["id"]; ok || pl.VC.Issuer != "" || pl.VC.IssuanceDate != nil {
+		t.Errorf("vc.NewCredentialPayload didn't move properties to claims: %+v", pl.VC)
+	}
+	if got := pl.Credential(); !cmp.Equal(got, cred) {
+		t.Errorf("vc.CredentialPayload.Credential mismatch (-want +got):\n%s", cmp.Diff(cred, got))
+	}
+}
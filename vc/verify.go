@@ -0,0 +1,164 @@
+package vc
+
+import (
+	"bytes"
+	"encoding/json"
+	"time"
+
+	"github.com/gbrlsnchs/jwt/v3"
+	"github.com/gbrlsnchs/jwt/v3/internal"
+	"github.com/gbrlsnchs/jwt/v3/jwtutil"
+)
+
+var (
+	// ErrNonceValidation is the error for an invalid "nonce" claim.
+	ErrNonceValidation = internal.NewError("vc: nonce claim is invalid")
+	// ErrHolderValidation is the error for a credential whose subject is not the presentation's holder.
+	ErrHolderValidation = internal.NewError("vc: credential subject is not the holder")
+	// ErrNoIssuer is the error for verifying a credential with a Verifier whose Issuer is nil.
+	ErrNoIssuer = internal.NewError("vc: verifier has no issuer")
+)
+
+// Verifier verifies credentials and presentations.
+type Verifier struct {
+	// Issuer resolves the algorithm that verifies a credential issued by iss based on its header,
+	// usually by looking up its "kid" in the keys published by iss. It must only return keys
+	// that belong to iss, otherwise any trusted issuer could issue credentials on behalf of another.
+	Issuer func(iss string, hd jwt.Header) (jwt.Algorithm, error)
+	// Audience is the expected "aud" claim of presentations. When empty, "aud" is not validated.
+	Audience string
+	// Nonce is the expected "nonce" claim of presentations.
+	Nonce string
+	// SkipHolderBinding disables checking that the subject of every credential is the presentation's holder.
+	//
+	// WARNING: when set, a holder can present credentials about other subjects as their own,
+	// so it should only be set when the holder is checked otherwise.
+	SkipHolderBinding bool
+	// Now returns the current time. Defaults to time.Now.
+	Now func() time.Time
+}
+
+func (v *Verifier) now() time.Time {
+	if v.Now != nil {
+		return v.Now()
+	}
+	return time.Now()
+}
+
+// VerifyCredential verifies a credential with a key resolved by Issuer for the credential's "iss" claim.
+func (v *Verifier) VerifyCredential(token []byte, vds ...jwt.Validator) (*CredentialPayload, error) {
+	if v.Issuer == nil {
+		return nil, ErrNoIssuer
+	}
+	iss, err := unverifiedIssuer(token)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		pl  CredentialPayload
+		now = v.now()
+	)
+	vds = append([]jwt.Validator{
+		func(_ *jwt.Payload) error {
+			if pl.VC == nil || !contains(pl.VC.Type, CredentialType) {
+				return ErrVCValidation
+			}
+			return nil
+		},
+		jwt.IssuerValidator(iss),
+		optionalExpirationTimeValidator(now),
+		jwt.NotBeforeValidator(now),
+	}, vds...)
+	// A new Resolver is needed for every credential since it caches the resolved algorithm.
+	rv := &jwtutil.Resolver{New: func(hd jwt.Header) (jwt.Algorithm, error) {
+		return v.Issuer(iss, hd)
+	}}
+	if _, err = jwt.Verify(token, rv, &pl, jwt.ValidateHeader, jwt.ValidatePayload(&pl.Payload, vds...)); err != nil {
+		return nil, err
+	}
+	return &pl, nil
+}
+
+// VerifyPresentation verifies a presentation with the holder's alg and then every
+// credential it contains. Validators in vds are only run against the presentation.
+func (v *Verifier) VerifyPresentation(token []byte, alg jwt.Algorithm, vds ...jwt.Validator) (*PresentationPayload, []*CredentialPayload, error) {
+	var (
+		pl  PresentationPayload
+		now = v.now()
+	)
+	vds = append([]jwt.Validator{
+		func(_ *jwt.Payload) error {
+			if pl.VP == nil || !contains(pl.VP.Type, PresentationType) {
+				return ErrVPValidation
+			}
+			if pl.Nonce != v.Nonce {
+				return ErrNonceValidation
+			}
+			return nil
+		},
+		optionalExpirationTimeValidator(now),
+		jwt.NotBeforeValidator(now),
+		jwt.IssuedAtValidator(now),
+	}, vds...)
+	if v.Audience != "" {
+		vds = append(vds, jwt.AudienceValidator(jwt.Audience{v.Audience}))
+	}
+	if _, err := jwt.Verify(token, alg, &pl, jwt.ValidateHeader, jwt.ValidatePayload(&pl.Payload, vds...)); err != nil {
+		return nil, nil, err
+	}
+	creds := make([]*CredentialPayload, len(pl.VP.VerifiableCredential))
+	for i, vc := range pl.VP.VerifiableCredential {
+		cred, err := v.VerifyCredential([]byte(vc))
+		if err != nil {
+			return nil, nil, internal.Errorf("vc: credential %d: %w", i, err)
+		}
+		// Subjects and holders are optional, but a credential about no one isn't bound to anyone.
+		if !v.SkipHolderBinding && (pl.Issuer == "" || cred.Subject != pl.Issuer) {
+			return nil, nil, ErrHolderValidation
+		}
+		creds[i] = cred
+	}
+	return &pl, creds, nil
+}
+
+// unverifiedIssuer reads the "iss" claim of token before verifying it,
+// so that its key is resolved for that issuer only.
+func unverifiedIssuer(token []byte) (string, error) {
+	parts := bytes.Split(token, []byte("."))
+	if len(parts) != 3 {
+		return "", jwt.ErrMalformed
+	}
+	pb, err := internal.DecodeToBytes(parts[1])
+	if err != nil {
+		return "", err
+	}
+	var pl jwt.Payload
+	if err = json.Unmarshal(pb, &pl); err != nil {
+		return "", err
+	}
+	if pl.Issuer == "" {
+		return "", jwt.ErrIssValidation
+	}
+	return pl.Issuer, nil
+}
+
+// optionalExpirationTimeValidator validates the "exp" claim only when present,
+// since credentials don't need to expire.
+func optionalExpirationTimeValidator(now time.Time) jwt.Validator {
+	vd := jwt.ExpirationTimeValidator(now)
+	return func(pl *jwt.Payload) error {
+		if pl.ExpirationTime == nil {
+			return nil
+		}
+		return vd(pl)
+	}
+}
+
+func contains(vs []string, v string) bool {
+	for _, vv := range vs {
+		if vv == v {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,183 @@
+package vc_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gbrlsnchs/jwt/v3"
+	"github.com/gbrlsnchs/jwt/v3/internal"
+	"github.com/gbrlsnchs/jwt/v3/vc"
+	"github.com/google/go-cmp/cmp"
+)
+
+var (
+	issuerKey, _ = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	holderKey, _ = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	issuerES256  = jwt.NewES256(jwt.ECDSAPrivateKey(issuerKey))
+	holderES256  = jwt.NewES256(jwt.ECDSAPrivateKey(holderKey))
+
+	errUnknownKey = errors.New("unknown key")
+)
+
+func resolveIssuer(iss string, hd jwt.Header) (jwt.Algorithm, error) {
+	if iss != "https://example.edu/issuers/14" || hd.KeyID != "https://example.edu/issuers/14#key-1" {
+		return nil, errUnknownKey
+	}
+	return jwt.NewES256(jwt.ECDSAPublicKey(&issuerKey.PublicKey)), nil
+}
+
+func TestVerifyPresentation(t *testing.T) {
+	kid := jwt.KeyID("https://example.edu/issuers/14#key-1")
+	signCred := func(cred vc.Credential, alg jwt.Algorithm) string {
+		token, err := vc.SignCredential(cred, alg, kid)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(token)
+	}
+	valid := signCred(newCredential("did:example:holder", time.Now().Add(time.Hour)), issuerES256)
+	otherIssuer := newCredential("did:example:holder", time.Now().Add(time.Hour))
+	otherIssuer.Issuer = "https://example.com/issuers/1"
+	aud := "https://verifier.example.com"
+	testCases := []struct {
+		name     string
+		creds    []string
+		holder   string
+		nonce    string
+		aud      string
+		verifier vc.Verifier
+		err      error
+	}{
+		{
+			name:     "valid",
+			creds:    []string{valid},
+			holder:   "did:example:holder",
+			nonce:    "nonce",
+			verifier: vc.Verifier{Audience: aud},
+			err:      nil,
+		},
+		{
+			name:     "wrong nonce",
+			creds:    []string{valid},
+			holder:   "did:example:holder",
+			nonce:    "other",
+			verifier: vc.Verifier{Audience: aud},
+			err:      vc.ErrNonceValidation,
+		},
+		{
+			name:     "not the holder",
+			creds:    []string{valid},
+			holder:   "did:example:other",
+			nonce:    "nonce",
+			verifier: vc.Verifier{Audience: aud},
+			err:      vc.ErrHolderValidation,
+		},
+		{
+			name:     "no holder",
+			creds:    []string{signCred(newCredential("", time.Now().Add(time.Hour)), issuerES256)},
+			holder:   "",
+			nonce:    "nonce",
+			verifier: vc.Verifier{Audience: aud},
+			err:      vc.ErrHolderValidation,
+		},
+		{
+			name:     "expired credential",
+			creds:    []string{signCred(newCredential("did:example:holder", time.Now().Add(-time.Hour)), issuerES256)},
+			holder:   "did:example:holder",
+			nonce:    "nonce",
+			verifier: vc.Verifier{Audience: aud},
+			err:      jwt.ErrExpValidation,
+		},
+		{
+			name:     "forged credential",
+			creds:    []string{signCred(newCredential("did:example:holder", time.Now().Add(time.Hour)), holderES256)},
+			holder:   "did:example:holder",
+			nonce:    "nonce",
+			verifier: vc.Verifier{Audience: aud},
+			err:      jwt.ErrECDSAVerification,
+		},
+		{
+			name:     "credential from another issuer",
+			creds:    []string{signCred(otherIssuer, issuerES256)},
+			holder:   "did:example:holder",
+			nonce:    "nonce",
+			verifier: vc.Verifier{Audience: aud},
+			err:      errUnknownKey,
+		},
+		{
+			name:     "holder binding skipped",
+			creds:    []string{valid},
+			holder:   "did:example:other",
+			nonce:    "nonce",
+			verifier: vc.Verifier{Audience: aud, SkipHolderBinding: true},
+			err:      nil,
+		},
+		{
+			name:     "wrong audience",
+			creds:    []string{valid},
+			holder:   "did:example:holder",
+			nonce:    "nonce",
+			aud:      "https://other.example.com",
+			verifier: vc.Verifier{Audience: aud},
+			err:      jwt.ErrAudValidation,
+		},
+		{
+			name:     "any audience",
+			creds:    []string{valid},
+			holder:   "did:example:holder",
+			nonce:    "nonce",
+			aud:      "https://other.example.com",
+			verifier: vc.Verifier{},
+			err:      nil,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			vp := vc.Presentation{
+				Context:              []string{vc.ContextV1},
+				Type:                 []string{vc.PresentationType},
+				Holder:               tc.holder,
+				VerifiableCredential: tc.creds,
+			}
+			if tc.aud == "" {
+				tc.aud = aud
+			}
+			token, err := vc.SignPresentation(vp, holderES256, tc.aud, tc.nonce)
+			if err != nil {
+				t.Fatal(err)
+			}
+			v := tc.verifier
+			v.Issuer = resolveIssuer
+			v.Nonce = "nonce"
+			pl, creds, err := v.VerifyPresentation(token, jwt.NewES256(jwt.ECDSAPublicKey(&holderKey.PublicKey)))
+			if want, got := tc.err, err; !internal.ErrorIs(got, want) {
+				t.Fatalf("vc.Verifier.VerifyPresentation err mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+			if err != nil {
+				return
+			}
+			if want, got := tc.holder, pl.Issuer; got != want {
+				t.Errorf("holder mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+			if want, got := "BachelorDegree", creds[0].Credential().CredentialSubject["degree"].(map[string]interface{})["type"]; got != want {
+				t.Errorf("credential subject mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+		})
+	}
+}
+
+func TestVerifyCredentialNoIssuer(t *testing.T) {
+	token, err := vc.SignCredential(newCredential("did:example:holder", time.Now().Add(time.Hour)), issuerES256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var v vc.Verifier
+	_, err = v.VerifyCredential(token)
+	if want, got := vc.ErrNoIssuer, err; !internal.ErrorIs(got, want) {
+		t.Errorf("vc.Verifier.VerifyCredential err mismatch (-want +got):\n%s", cmp.Diff(want, got))
+	}
+}
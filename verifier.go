@@ -0,0 +1,206 @@
+package jwt
+
+import (
+	"bytes"
+	"encoding/base64"
+	"math/big"
+	"sync"
+
+	"github.com/gbrlsnchs/jwt/v3/internal"
+)
+
+const (
+	// maxCachedHeaders is the number of decoded headers a Verifier keeps before starting over.
+	maxCachedHeaders = 64
+	// maxCachedTokens is the number of verified tokens a Verifier keeps before starting over.
+	maxCachedTokens = 1024
+)
+
+// ErrVerifierResolver is the error for creating a Verifier with a Resolver, which would be
+// resolved once and shared by every token, regardless of their headers.
+var ErrVerifierResolver = internal.NewError("jwt: Verifier doesn't support Resolver algorithms")
+
+// verifyScratch is the memory reused between verifications.
+type verifyScratch struct {
+	rt      RawToken
+	sig     []byte
+	sum     []byte
+	payload []byte
+	r, s    big.Int
+}
+
+// scratchPool holds the memory used by Verifier and by the Verify methods of scratchVerifier algorithms.
+var scratchPool = sync.Pool{New: func() interface{} { return new(verifyScratch) }}
+
+// scratchVerifier is implemented by algorithms that can verify
+// a decoded signature using scratch memory instead of allocating.
+type scratchVerifier interface {
+	verify(headerPayload, sig []byte, sc *verifyScratch) error
+}
+
+var (
+	_ scratchVerifier = new(HMACSHA)
+	_ scratchVerifier = new(ECDSASHA)
+)
+
+// Verifier verifies tokens signed with a single algorithm while reusing memory between calls,
+// which suits services verifying lots of tokens. It is safe for concurrent use.
+//
+// Signatures are decoded into pooled buffers, and headers of tokens that have been successfully
+// verified are cached by their encoded form. Since algorithms other than HMAC-SHA allocate inside
+// the crypto packages they use, tokens they have successfully verified are cached as well, so that
+// verifying them again only decodes and validates their payload. Thus, AppendPayload doesn't allocate
+// for HMAC-SHA algorithms once a header has been seen, nor for other algorithms once a token has been seen,
+// as long as dst is large enough.
+type Verifier struct {
+	alg         Algorithm
+	cacheTokens bool
+
+	mu      sync.RWMutex
+	headers map[string]Header
+	tokens  map[string]struct{}
+}
+
+// NewVerifier creates a Verifier that verifies tokens using alg.
+// Resolvers are rejected with ErrVerifierResolver, since those can't be shared between tokens.
+func NewVerifier(alg Algorithm) (*Verifier, error) {
+	if _, ok := alg.(Resolver); ok {
+		return nil, ErrVerifierResolver
+	}
+	_, hmac := alg.(*HMACSHA)
+	return &Verifier{
+		alg:         alg,
+		cacheTokens: !hmac,
+		headers:     make(map[string]Header),
+		tokens:      make(map[string]struct{}),
+	}, nil
+}
+
+// Verify is like the Verify function, but reuses memory between calls.
+func (v *Verifier) Verify(token []byte, payload interface{}, opts ...VerifyOption) (Header, error) {
+	sc := scratchPool.Get().(*verifyScratch)
+	defer putScratch(sc)
+
+	hd, err := v.verify(sc, token, opts)
+	if err != nil {
+		return hd, err
+	}
+	if sc.payload, err = decodeAppend(sc.payload[:0], sc.rt.payload()); err != nil {
+		return hd, err
+	}
+	return hd, sc.rt.unmarshal(sc.payload, payload)
+}
+
+// AppendPayload verifies token like Verify, but instead of decoding the payload into a struct,
+// it appends the payload's JSON to dst and returns the extended buffer, leaving decoding up to the caller.
+// If opts include ValidatePayload, its Payload is decoded from the JSON before running its validators.
+func (v *Verifier) AppendPayload(dst, token []byte, opts ...VerifyOption) ([]byte, Header, error) {
+	sc := scratchPool.Get().(*verifyScratch)
+	defer putScratch(sc)
+
+	hd, err := v.verify(sc, token, opts)
+	if err != nil {
+		return dst, hd, err
+	}
+	n := len(dst)
+	if dst, err = decodeAppend(dst, sc.rt.payload()); err != nil {
+		return dst[:n], hd, err
+	}
+	if sc.rt.pl != nil {
+		if err = sc.rt.unmarshal(dst[n:], sc.rt.pl); err != nil {
+			return dst[:n], hd, err
+		}
+	}
+	return dst, hd, nil
+}
+
+// putScratch returns sc to the pool without holding on to the caller's token and options.
+func putScratch(sc *verifyScratch) {
+	sc.rt = RawToken{}
+	scratchPool.Put(sc)
+}
+
+func (v *Verifier) verify(sc *verifyScratch, token []byte, opts []VerifyOption) (Header, error) {
+	sc.rt = RawToken{alg: v.alg}
+	rt := &sc.rt
+
+	sep1 := bytes.IndexByte(token, '.')
+	if sep1 < 0 {
+		return rt.hd, ErrMalformed
+	}
+	sep2 := bytes.IndexByte(token[sep1+1:], '.')
+	if sep2 < 0 {
+		return rt.hd, ErrMalformed
+	}
+	rt.setToken(token, sep1, sep2)
+
+	v.mu.RLock()
+	hd, cached := v.headers[string(rt.header())]
+	v.mu.RUnlock()
+	if cached {
+		rt.hd = hd.clone()
+	} else if err := rt.decodeHeader(); err != nil {
+		return rt.hd, err
+	}
+
+	var err error
+	for _, opt := range opts {
+		if err = opt(rt); err != nil {
+			return rt.hd, err
+		}
+	}
+
+	verified := false
+	if v.cacheTokens {
+		v.mu.RLock()
+		_, verified = v.tokens[string(token)]
+		v.mu.RUnlock()
+	}
+	if !verified {
+		if sv, ok := v.alg.(scratchVerifier); ok {
+			if sc.sig, err = decodeAppend(sc.sig[:0], rt.sig()); err != nil {
+				return rt.hd, err
+			}
+			err = sv.verify(rt.headerPayload(), sc.sig, sc)
+		} else {
+			err = v.alg.Verify(rt.headerPayload(), rt.sig())
+		}
+		if err != nil {
+			return rt.hd, err
+		}
+	}
+
+	if !cached || (v.cacheTokens && !verified) {
+		v.mu.Lock()
+		if !cached {
+			if len(v.headers) >= maxCachedHeaders {
+				v.headers = make(map[string]Header)
+			}
+			v.headers[string(rt.header())] = rt.hd.clone()
+		}
+		if v.cacheTokens && !verified {
+			if len(v.tokens) >= maxCachedTokens {
+				v.tokens = make(map[string]struct{})
+			}
+			v.tokens[string(token)] = struct{}{}
+		}
+		v.mu.Unlock()
+	}
+	return rt.hd, nil
+}
+
+// decodeAppend decodes Base64 encoded src and appends the result to dst.
+func decodeAppend(dst, src []byte) ([]byte, error) {
+	enc := base64.RawURLEncoding
+	n := len(dst)
+	if size := n + enc.DecodedLen(len(src)); cap(dst) < size {
+		grown := make([]byte, n, size)
+		copy(grown, dst)
+		dst = grown
+	}
+	m, err := enc.Decode(dst[n:cap(dst)], src)
+	if err != nil {
+		return dst[:n], err
+	}
+	return dst[:n+m], nil
+}
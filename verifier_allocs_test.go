@@ -0,0 +1,63 @@
+// +build !race
+
+package jwt_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/gbrlsnchs/jwt/v3"
+)
+
+// TestVerifierAllocs doesn't run with the race detector, which makes sync.Pool drop items at random.
+func TestVerifierAllocs(t *testing.T) {
+	testCases := []jwt.Algorithm{
+		jwt.NewHS256(hmacKey1),
+		jwt.NewES256(jwt.ECDSAPrivateKey(es256PrivateKey1)),
+	}
+	for _, alg := range testCases {
+		t.Run(alg.Name(), func(t *testing.T) {
+			token, err := jwt.Sign(tp, alg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			vr, err := jwt.NewVerifier(alg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			dst := make([]byte, 0, len(token))
+			if _, _, err = vr.AppendPayload(dst, token); err != nil {
+				t.Fatal(err)
+			}
+			allocs := testing.AllocsPerRun(100, func() {
+				if _, _, err := vr.AppendPayload(dst[:0], token); err != nil {
+					t.Fatal(err)
+				}
+			})
+			if allocs != 0 {
+				t.Errorf("jwt.Verifier.AppendPayload allocations: want 0, got %v", allocs)
+			}
+		})
+	}
+}
+
+func TestHMACSHAVerifyAllocs(t *testing.T) {
+	alg := jwt.NewHS256(hmacKey1)
+	token, err := jwt.Sign(tp, alg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sep := bytes.LastIndexByte(token, '.')
+	headerPayload, sig := token[:sep], token[sep+1:]
+	if err = alg.Verify(headerPayload, sig); err != nil {
+		t.Fatal(err)
+	}
+	allocs := testing.AllocsPerRun(100, func() {
+		if err := alg.Verify(headerPayload, sig); err != nil {
+			t.Fatal(err)
+		}
+	})
+	if allocs != 0 {
+		t.Errorf("jwt.HMACSHA.Verify allocations: want 0, got %v", allocs)
+	}
+}
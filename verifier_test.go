@@ -0,0 +1,162 @@
+package jwt_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/gbrlsnchs/jwt/v3"
+	"github.com/gbrlsnchs/jwt/v3/internal"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestVerifier(t *testing.T) {
+	testCases := map[string][]testCase{
+		"HMAC":    hmacTestCases,
+		"RSA":     rsaTestCases,
+		"RSA-PSS": rsaPSSTestCases,
+		"ECDSA":   ecdsaTestCases,
+		"Ed25519": ed25519TestCases,
+		"Ed448":   ed448TestCases,
+	}
+	for k, v := range testCases {
+		t.Run(k, func(t *testing.T) {
+			for _, tc := range v {
+				t.Run(tc.verifyAlg.Name(), func(t *testing.T) {
+					token, err := jwt.Sign(tc.payload, tc.alg)
+					if err != nil {
+						t.Fatal(err)
+					}
+					vr, err := jwt.NewVerifier(tc.verifyAlg)
+					if err != nil {
+						t.Fatal(err)
+					}
+					// Verify twice so that the cached header and token are also used.
+					for i := 0; i < 2; i++ {
+						var pl testPayload
+						hd, err := vr.Verify(token, &pl)
+						if want, got := tc.verifyErr, err; got != want {
+							t.Errorf("jwt.Verifier.Verify err mismatch (-want +got):\n%s", cmp.Diff(want, got))
+						}
+						if want, got := tc.wantHeader, hd; !cmp.Equal(got, want) {
+							t.Errorf("jwt.Verifier.Verify header mismatch (-want +got):\n%s", cmp.Diff(want, got))
+						}
+						if want, got := tc.wantPayload, pl; !cmp.Equal(got, want) {
+							t.Errorf("jwt.Verifier.Verify payload mismatch (-want +got):\n%s", cmp.Diff(want, got))
+						}
+					}
+				})
+			}
+		})
+	}
+}
+
+func TestVerifierAppendPayload(t *testing.T) {
+	now := time.Now()
+	vr, err := jwt.NewVerifier(jwt.NewHS256(hmacKey1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	token, err := jwt.Sign(tp, jwt.NewHS256(hmacKey1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	testCases := []struct {
+		name  string
+		token []byte
+		sub   string
+		err   error
+	}{
+		{"valid", token, "someone", nil},
+		{"invalid subject", token, "someone else", jwt.ErrSubValidation},
+		{"malformed", []byte("header.payload"), "someone", jwt.ErrMalformed},
+		{"invalid signature", append(token[:len(token)-4:len(token)-4], "AAAA"...), "someone", jwt.ErrHMACVerification},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var pl jwt.Payload
+			dst := []byte("prefix")
+			dst, _, err := vr.AppendPayload(dst, tc.token,
+				jwt.ValidatePayload(&pl, jwt.SubjectValidator(tc.sub), jwt.ExpirationTimeValidator(now)))
+			if want, got := tc.err, err; !internal.ErrorIs(got, want) {
+				t.Fatalf("jwt.Verifier.AppendPayload err mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+			if err != nil {
+				if want, got := "prefix", string(dst); got != want {
+					t.Errorf("jwt.Verifier.AppendPayload dst mismatch (-want +got):\n%s", cmp.Diff(want, got))
+				}
+				return
+			}
+			var got testPayload
+			if err = json.Unmarshal(dst[len("prefix"):], &got); err != nil {
+				t.Fatal(err)
+			}
+			if want := tp; !cmp.Equal(got, want) {
+				t.Errorf("jwt.Verifier.AppendPayload payload mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+		})
+	}
+}
+
+func TestVerifierTokenCache(t *testing.T) {
+	alg := jwt.NewES256(jwt.ECDSAPrivateKey(es256PrivateKey1))
+	token, err := jwt.Sign(tp, alg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	vr, err := jwt.NewVerifier(alg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var pl testPayload
+	if _, err = vr.Verify(token, &pl); err != nil {
+		t.Fatal(err)
+	}
+	// The header and payload are the same as the cached token's, but the signature isn't.
+	forged := append(token[:len(token)-4:len(token)-4], "AAAA"...)
+	_, err = vr.Verify(forged, &pl)
+	if want, got := jwt.ErrECDSAVerification, err; !internal.ErrorIs(got, want) {
+		t.Errorf("jwt.Verifier.Verify err mismatch (-want +got):\n%s", cmp.Diff(want, got))
+	}
+}
+
+// resolver is a jwt.Resolver that always resolves to the same algorithm.
+type resolver struct {
+	jwt.Algorithm
+}
+
+func (resolver) Resolve(jwt.Header) error { return nil }
+
+func TestNewVerifierResolver(t *testing.T) {
+	_, err := jwt.NewVerifier(resolver{jwt.NewHS256(hmacKey1)})
+	if want, got := jwt.ErrVerifierResolver, err; !internal.ErrorIs(got, want) {
+		t.Errorf("jwt.NewVerifier err mismatch (-want +got):\n%s", cmp.Diff(want, got))
+	}
+}
+
+func TestVerifierHeaderCopy(t *testing.T) {
+	jwk, err := jwt.NewJWK(es256PublicKey1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	alg := jwt.NewHS256(hmacKey1)
+	token, err := jwt.Sign(tp, alg, jwt.JSONWebKey(jwk))
+	if err != nil {
+		t.Fatal(err)
+	}
+	vr, err := jwt.NewVerifier(alg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 2; i++ {
+		var pl testPayload
+		hd, err := vr.Verify(token, &pl)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want, got := jwk, hd.JSONWebKey; !cmp.Equal(got, want) {
+			t.Errorf("jwt.Verifier.Verify jwk mismatch (-want +got):\n%s", cmp.Diff(want, got))
+		}
+		hd.JSONWebKey.X = "modified"
+	}
+}
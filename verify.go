@@ -2,6 +2,7 @@ package jwt
 
 import (
 	"bytes"
+	"strings"
 
 	"github.com/gbrlsnchs/jwt/v3/internal"
 )
@@ -9,6 +10,9 @@ import (
 // ErrAlgValidation indicates an incoming JWT's "alg" field mismatches the Validator's.
 var ErrAlgValidation = internal.NewError(`invalid "alg" field`)
 
+// ErrTypValidation indicates an incoming JWT's "typ" field mismatches the expected media type.
+var ErrTypValidation = internal.NewError(`invalid "typ" field`)
+
 // VerifyOption is a functional option for verifying.
 type VerifyOption func(*RawToken) error
 
@@ -60,6 +64,27 @@ func ValidateHeader(rt *RawToken) error {
 	return nil
 }
 
+// ValidateType checks whether the "typ" field contained in the JOSE header
+// matches typ. As per the RFC 7515, the comparison is case-insensitive and
+// the "application/" prefix may be omitted.
+func ValidateType(typ string) VerifyOption {
+	typ = mediaType(typ)
+	return func(rt *RawToken) error {
+		if mediaType(rt.hd.Type) != typ {
+			return internal.Errorf("jwt: %q: %w", rt.hd.Type, ErrTypValidation)
+		}
+		return nil
+	}
+}
+
+func mediaType(typ string) string {
+	typ = strings.ToLower(typ)
+	if !strings.Contains(typ, "/") {
+		return "application/" + typ
+	}
+	return typ
+}
+
 // ValidatePayload runs validators against a Payload after it's been decoded.
 func ValidatePayload(pl *Payload, vds ...Validator) VerifyOption {
 	return func(rt *RawToken) error {
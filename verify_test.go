@@ -53,6 +53,7 @@ func TestVerify(t *testing.T) {
 		"RSA-PSS": rsaPSSTestCases,
 		"ECDSA":   ecdsaTestCases,
 		"Ed25519": ed25519TestCases,
+		"Ed448":   ed448TestCases,
 	}
 	for k, v := range testCases {
 		t.Run(k, func(t *testing.T) {
@@ -161,3 +162,34 @@ func TestValidatePayload(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateType(t *testing.T) {
+	testCases := []struct {
+		signOpts []jwt.SignOption
+		typ      string
+		err      error
+	}{
+		{nil, "JWT", nil},
+		{nil, "jwt", nil},
+		{nil, "application/jwt", nil},
+		{nil, "at+jwt", jwt.ErrTypValidation},
+		{[]jwt.SignOption{jwt.Type("at+jwt")}, "at+jwt", nil},
+		{[]jwt.SignOption{jwt.Type("application/at+jwt")}, "at+jwt", nil},
+		{[]jwt.SignOption{jwt.Type("at+jwt")}, "Application/AT+JWT", nil},
+		{[]jwt.SignOption{jwt.Type("at+jwt")}, "JWT", jwt.ErrTypValidation},
+	}
+	hs256 := jwt.NewHS256([]byte("secret"))
+	for _, tc := range testCases {
+		t.Run(tc.typ, func(t *testing.T) {
+			token, err := jwt.Sign(jwt.Payload{}, hs256, tc.signOpts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var pl jwt.Payload
+			_, err = jwt.Verify(token, hs256, &pl, jwt.ValidateType(tc.typ))
+			if want, got := tc.err, err; !internal.ErrorIs(got, want) {
+				t.Errorf("jwt.Verify with jwt.ValidateType mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+		})
+	}
+}
@@ -0,0 +1,124 @@
+package webhook
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/gbrlsnchs/jwt/v3"
+	"github.com/gbrlsnchs/jwt/v3/internal"
+	"github.com/gbrlsnchs/jwt/v3/jwtutil"
+)
+
+const (
+	defaultMaxAge      = 5 * time.Minute
+	defaultMaxBodySize = 1 << 20
+)
+
+var (
+	// ErrBodyTooLarge is the error for a request body larger than the receiver accepts.
+	ErrBodyTooLarge = internal.NewError("webhook: request body is too large")
+	// ErrNoAlgorithm is the error for a Receiver with no Algorithm.
+	ErrNoAlgorithm = internal.NewError("webhook: receiver has no algorithm")
+)
+
+// Receiver verifies signed webhook requests.
+type Receiver struct {
+	// Algorithm verifies signatures. When it is a *jwtutil.Resolver, a copy of it
+	// is resolved for every request.
+	Algorithm jwt.Algorithm
+	// MaxAge is how long after being signed a request is accepted. Defaults to 5 minutes.
+	MaxAge time.Duration
+	// Leeway is the accepted clock skew between sender and receiver.
+	Leeway time.Duration
+	// MaxBodySize is the largest request body accepted, in bytes. Defaults to 1 MiB.
+	MaxBodySize int64
+	// Cache records the "jti" of accepted signatures so requests can only be delivered once.
+	// When nil, replays are not detected.
+	Cache jwtutil.ReplayCache
+	// Now returns the current time. Defaults to time.Now.
+	Now func() time.Time
+}
+
+// VerifyRequest verifies the signature of r. The body is read and replaced, so it can still be read.
+// When rc has no Algorithm, ErrNoAlgorithm is returned.
+func (rc *Receiver) VerifyRequest(r *http.Request) (Header, error) {
+	var hd Header
+	alg := rc.Algorithm
+	if alg == nil {
+		return hd, ErrNoAlgorithm
+	}
+	// A new Resolver is needed for every request since it caches the resolved algorithm.
+	if rv, ok := alg.(*jwtutil.Resolver); ok {
+		alg = &jwtutil.Resolver{New: rv.New}
+	}
+	jws := r.Header.Get(SignatureHeader)
+	if jws == "" {
+		return hd, ErrMissingSignature
+	}
+	maxBodySize := rc.MaxBodySize
+	if maxBodySize == 0 {
+		maxBodySize = defaultMaxBodySize
+	}
+	var body []byte
+	if r.Body != nil {
+		var err error
+		if body, err = ioutil.ReadAll(io.LimitReader(r.Body, maxBodySize+1)); err != nil {
+			return hd, err
+		}
+		if int64(len(body)) > maxBodySize {
+			return hd, ErrBodyTooLarge
+		}
+		r.Body.Close()
+		r.Body = ioutil.NopCloser(bytes.NewReader(body))
+	}
+	hd, err := Verify([]byte(jws), r.Method, r.URL.EscapedPath(), body, alg)
+	if err != nil {
+		return hd, err
+	}
+	now := time.Now()
+	if rc.Now != nil {
+		now = rc.Now()
+	}
+	maxAge := rc.MaxAge
+	if maxAge == 0 {
+		maxAge = defaultMaxAge
+	}
+	iat := time.Unix(hd.IssuedAt, 0)
+	if iat.After(now.Add(rc.Leeway)) || iat.Before(now.Add(-maxAge-rc.Leeway)) {
+		return hd, ErrStaleSignature
+	}
+	if rc.Cache != nil {
+		if hd.ID == "" {
+			return hd, jwt.ErrJtiValidation
+		}
+		seen, err := rc.Cache.Seen(hd.ID, iat.Add(maxAge+rc.Leeway))
+		if err != nil {
+			return hd, err
+		}
+		if seen {
+			return hd, internal.Errorf("webhook: %q: %w", hd.ID, jwtutil.ErrReplay)
+		}
+	}
+	return hd, nil
+}
+
+// Handler wraps next so that it only serves requests whose signature is valid.
+// Other requests are answered with 401 Unauthorized or, when rc has no Algorithm,
+// with 500 Internal Server Error.
+func (rc *Receiver) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, err := rc.VerifyRequest(r)
+		if internal.ErrorIs(err, ErrNoAlgorithm) {
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+		if err != nil {
+			http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,157 @@
+package webhook_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gbrlsnchs/jwt/v3"
+	"github.com/gbrlsnchs/jwt/v3/internal"
+	"github.com/gbrlsnchs/jwt/v3/jwtutil"
+	"github.com/gbrlsnchs/jwt/v3/webhook"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestReceiver(t *testing.T) {
+	const body = `{"event":"push"}`
+	now := time.Now()
+	testCases := []struct {
+		name     string
+		receiver *webhook.Receiver
+		opts     []webhook.SignOption
+		unsigned bool
+		replay   bool
+		body     string
+		err      error
+	}{
+		{
+			name:     "valid",
+			receiver: &webhook.Receiver{Algorithm: es256},
+			body:     body,
+			err:      nil,
+		},
+		{
+			name:     "unsigned",
+			receiver: &webhook.Receiver{Algorithm: es256},
+			unsigned: true,
+			body:     body,
+			err:      webhook.ErrMissingSignature,
+		},
+		{
+			name:     "stale",
+			receiver: &webhook.Receiver{Algorithm: es256},
+			opts:     []webhook.SignOption{webhook.IssuedAt(now.Add(-time.Hour))},
+			body:     body,
+			err:      webhook.ErrStaleSignature,
+		},
+		{
+			name:     "issued in the future",
+			receiver: &webhook.Receiver{Algorithm: es256, Leeway: 5 * time.Second},
+			opts:     []webhook.SignOption{webhook.IssuedAt(now.Add(time.Minute))},
+			body:     body,
+			err:      webhook.ErrStaleSignature,
+		},
+		{
+			name:     "replay",
+			receiver: &webhook.Receiver{Algorithm: es256, Cache: jwtutil.NewMemoryCache()},
+			opts:     []webhook.SignOption{webhook.ID("foobar")},
+			replay:   true,
+			body:     body,
+			err:      jwtutil.ErrReplay,
+		},
+		{
+			name:     "no algorithm",
+			receiver: &webhook.Receiver{},
+			body:     body,
+			err:      webhook.ErrNoAlgorithm,
+		},
+		{
+			name: "resolver",
+			receiver: &webhook.Receiver{Algorithm: jwtutil.NewResolver(func(hd jwt.Header) (interface{}, error) {
+				return &es256PrivateKey.PublicKey, nil
+			})},
+			replay: true,
+			body:   body,
+			err:    nil,
+		},
+		{
+			name:     "body too large",
+			receiver: &webhook.Receiver{Algorithm: es256, MaxBodySize: 8},
+			body:     body,
+			err:      webhook.ErrBodyTooLarge,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			newRequest := func() *http.Request {
+				r := httptest.NewRequest(http.MethodPost, "/hooks", bytes.NewBufferString(tc.body))
+				if !tc.unsigned {
+					if err := webhook.SignRequest(r, es256, tc.opts...); err != nil {
+						t.Fatal(err)
+					}
+				}
+				return r
+			}
+			r := newRequest()
+			if tc.replay {
+				if _, err := tc.receiver.VerifyRequest(r); err != nil {
+					t.Fatal(err)
+				}
+				r = newRequest()
+			}
+			_, err := tc.receiver.VerifyRequest(r)
+			if want, got := tc.err, err; !internal.ErrorIs(got, want) {
+				t.Errorf("webhook.Receiver.VerifyRequest err mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+		})
+	}
+}
+
+func TestReceiverHandler(t *testing.T) {
+	const body = `{"event":"push"}`
+	rc := &webhook.Receiver{Algorithm: es256}
+	h := rc.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want, got := body, string(b); got != want {
+			t.Errorf("body mismatch (-want +got):\n%s", cmp.Diff(want, got))
+		}
+	}))
+	testCases := []struct {
+		name   string
+		alg    jwt.Algorithm
+		status int
+	}{
+		{"valid", es256, http.StatusOK},
+		{"wrong key", hs256, http.StatusUnauthorized},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "/hooks", bytes.NewBufferString(body))
+			if err := webhook.SignRequest(r, tc.alg); err != nil {
+				t.Fatal(err)
+			}
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+			if want, got := tc.status, w.Code; got != want {
+				t.Errorf("status mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+		})
+	}
+	t.Run("no algorithm", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodPost, "/hooks", bytes.NewBufferString(body))
+		if err := webhook.SignRequest(r, es256); err != nil {
+			t.Fatal(err)
+		}
+		w := httptest.NewRecorder()
+		new(webhook.Receiver).Handler(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {})).ServeHTTP(w, r)
+		if want, got := http.StatusInternalServerError, w.Code; got != want {
+			t.Errorf("status mismatch (-want +got):\n%s", cmp.Diff(want, got))
+		}
+	})
+}
@@ -0,0 +1,163 @@
+// Package webhook signs and verifies webhook requests using detached JWS, as per the RFC 7515.
+//
+// The request body is the JWS payload, but it is not included in the signature,
+// which is sent in the SignatureHeader HTTP header. The request method, URL path
+// and time of signing are bound to the signature in the protected header.
+package webhook
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/gbrlsnchs/jwt/v3"
+	"github.com/gbrlsnchs/jwt/v3/internal"
+)
+
+// SignatureHeader is the HTTP header carrying the detached JWS of a webhook request.
+const SignatureHeader = "Webhook-Signature"
+
+var (
+	// ErrMissingSignature is the error for a request lacking the SignatureHeader HTTP header.
+	ErrMissingSignature = internal.NewError("webhook: missing signature")
+	// ErrMethodValidation is the error for a signature not matching the request method.
+	ErrMethodValidation = internal.NewError("webhook: htm header parameter is invalid")
+	// ErrPathValidation is the error for a signature not matching the request URL path.
+	ErrPathValidation = internal.NewError("webhook: htp header parameter is invalid")
+	// ErrStaleSignature is the error for a signature issued too long ago or in the future.
+	ErrStaleSignature = internal.NewError("webhook: signature is stale")
+)
+
+// Header is the protected header of a webhook signature.
+type Header struct {
+	Algorithm string `json:"alg"`
+	KeyID     string `json:"kid,omitempty"`
+	Method    string `json:"htm"`
+	Path      string `json:"htp"`
+	IssuedAt  int64  `json:"iat"`
+	ID        string `json:"jti"`
+}
+
+// SignOption is a functional option for signing webhook requests.
+type SignOption func(*Header)
+
+// KeyID sets the "kid" parameter of the signature's header.
+func KeyID(kid string) SignOption {
+	return func(hd *Header) {
+		hd.KeyID = kid
+	}
+}
+
+// IssuedAt sets the time of signing. Defaults to the current time.
+func IssuedAt(t time.Time) SignOption {
+	return func(hd *Header) {
+		hd.IssuedAt = t.Unix()
+	}
+}
+
+// ID sets the "jti" parameter of the signature's header. Defaults to a random value.
+func ID(jti string) SignOption {
+	return func(hd *Header) {
+		hd.ID = jti
+	}
+}
+
+// Sign returns the detached JWS of body, bound to method and path, signed with alg.
+func Sign(method, path string, body []byte, alg jwt.Algorithm, opts ...SignOption) ([]byte, error) {
+	hd := Header{Method: method, Path: path}
+	for _, opt := range opts {
+		opt(&hd)
+	}
+	hd.Algorithm = alg.Name()
+	if hd.IssuedAt == 0 {
+		hd.IssuedAt = time.Now().Unix()
+	}
+	if hd.ID == "" {
+		var err error
+		if hd.ID, err = internal.RandomID(16); err != nil {
+			return nil, err
+		}
+	}
+	hb, err := json.Marshal(hd)
+	if err != nil {
+		return nil, err
+	}
+	enc := base64.RawURLEncoding
+	h64 := make([]byte, enc.EncodedLen(len(hb)))
+	enc.Encode(h64, hb)
+	sig, err := alg.Sign(signingInput(h64, body))
+	if err != nil {
+		return nil, err
+	}
+	jws := make([]byte, len(h64)+2+enc.EncodedLen(len(sig)))
+	copy(jws, h64)
+	jws[len(h64)] = '.'
+	jws[len(h64)+1] = '.'
+	enc.Encode(jws[len(h64)+2:], sig)
+	return jws, nil
+}
+
+// SignRequest signs the body of r with alg and sets its signature in the SignatureHeader HTTP header.
+// The body is read and replaced, so it can still be sent.
+func SignRequest(r *http.Request, alg jwt.Algorithm, opts ...SignOption) error {
+	var body []byte
+	if r.Body != nil {
+		var err error
+		if body, err = ioutil.ReadAll(r.Body); err != nil {
+			return err
+		}
+		r.Body.Close()
+		r.Body = ioutil.NopCloser(bytes.NewReader(body))
+	}
+	jws, err := Sign(r.Method, r.URL.EscapedPath(), body, alg, opts...)
+	if err != nil {
+		return err
+	}
+	r.Header.Set(SignatureHeader, string(jws))
+	return nil
+}
+
+// Verify verifies jws is a detached JWS of body bound to method and path, signed with alg.
+// Freshness and replays are not checked. When alg is a jwt.Resolver, it is resolved
+// with the "alg" and "kid" parameters of the signature's header.
+func Verify(jws []byte, method, path string, body []byte, alg jwt.Algorithm) (Header, error) {
+	var hd Header
+	sep := bytes.Index(jws, []byte(".."))
+	if sep < 0 || bytes.IndexByte(jws[sep+2:], '.') >= 0 {
+		return hd, jwt.ErrMalformed
+	}
+	if err := internal.Decode(jws[:sep], &hd); err != nil {
+		return hd, err
+	}
+	if rv, ok := alg.(jwt.Resolver); ok {
+		if err := rv.Resolve(jwt.Header{Algorithm: hd.Algorithm, KeyID: hd.KeyID}); err != nil {
+			return hd, err
+		}
+	}
+	if hd.Algorithm != alg.Name() {
+		return hd, internal.Errorf("webhook: %q: %w", hd.Algorithm, jwt.ErrAlgValidation)
+	}
+	if err := alg.Verify(signingInput(jws[:sep], body), jws[sep+2:]); err != nil {
+		return hd, err
+	}
+	if hd.Method != method {
+		return hd, ErrMethodValidation
+	}
+	if hd.Path != path {
+		return hd, ErrPathValidation
+	}
+	return hd, nil
+}
+
+// signingInput returns the JWS signing input for the encoded header h64 and payload body.
+func signingInput(h64, body []byte) []byte {
+	enc := base64.RawURLEncoding
+	in := make([]byte, len(h64)+1+enc.EncodedLen(len(body)))
+	copy(in, h64)
+	in[len(h64)] = '.'
+	enc.Encode(in[len(h64)+1:], body)
+	return in
+}
@@ -0,0 +1,104 @@
+package webhook_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"testing"
+
+	"github.com/gbrlsnchs/jwt/v3"
+	"github.com/gbrlsnchs/jwt/v3/internal"
+	"github.com/gbrlsnchs/jwt/v3/webhook"
+	"github.com/google/go-cmp/cmp"
+)
+
+var (
+	hs256 = jwt.NewHS256([]byte("webhook"))
+
+	es256PrivateKey, _ = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	es256              = jwt.NewES256(jwt.ECDSAPrivateKey(es256PrivateKey))
+)
+
+func TestVerify(t *testing.T) {
+	body := []byte(`{"event":"push"}`)
+	testCases := []struct {
+		name   string
+		alg    jwt.Algorithm
+		method string
+		path   string
+		body   []byte
+		tamper func([]byte) []byte
+		err    error
+	}{
+		{
+			name:   "HS256",
+			alg:    hs256,
+			method: "POST",
+			path:   "/hooks",
+			body:   body,
+			err:    nil,
+		},
+		{
+			name:   "ES256",
+			alg:    es256,
+			method: "POST",
+			path:   "/hooks",
+			body:   body,
+			err:    nil,
+		},
+		{
+			name:   "wrong method",
+			alg:    hs256,
+			method: "PUT",
+			path:   "/hooks",
+			body:   body,
+			err:    webhook.ErrMethodValidation,
+		},
+		{
+			name:   "wrong path",
+			alg:    hs256,
+			method: "POST",
+			path:   "/other",
+			body:   body,
+			err:    webhook.ErrPathValidation,
+		},
+		{
+			name:   "tampered body",
+			alg:    hs256,
+			method: "POST",
+			path:   "/hooks",
+			body:   []byte(`{"event":"delete"}`),
+			err:    jwt.ErrHMACVerification,
+		},
+		{
+			name:   "attached payload",
+			alg:    hs256,
+			method: "POST",
+			path:   "/hooks",
+			body:   body,
+			tamper: func(_ []byte) []byte {
+				token, err := jwt.Sign(nil, hs256)
+				if err != nil {
+					t.Fatal(err)
+				}
+				return token
+			},
+			err: jwt.ErrMalformed,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			jws, err := webhook.Sign("POST", "/hooks", body, tc.alg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tc.tamper != nil {
+				jws = tc.tamper(jws)
+			}
+			_, err = webhook.Verify(jws, tc.method, tc.path, tc.body, tc.alg)
+			if want, got := tc.err, err; !internal.ErrorIs(got, want) {
+				t.Errorf("webhook.Verify err mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+		})
+	}
+}